@@ -0,0 +1,258 @@
+package awqlparse
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Placeholders returns the positions of the placeholders in the values of the condition.
+func (c *Where) Placeholders() []int {
+	return placeholders(c)
+}
+
+// placeholders returns the positions of the placeholders in the values of the condition.
+// A placeholder is a value literal, so a quoted question mark is not one.
+func placeholders(c Condition) (pos []int) {
+	val, lit := c.Value()
+	if !lit {
+		return
+	}
+	for i, v := range val {
+		if v == placeholder {
+			pos = append(pos, i)
+		}
+	}
+	return
+}
+
+// NumPlaceholder returns the number of placeholders in the conditions of the select statement.
+func (s SelectStatement) NumPlaceholder() (n int) {
+	for _, c := range s.ConditionList() {
+		n += len(placeholders(c))
+	}
+	return
+}
+
+// Bind returns a deep copy of the select statement with its placeholders replaced,
+// in order, by the arguments.
+// Numbers, booleans and dates are used as value literals, strings are quoted.
+// If a condition mixes them, all its values are quoted.
+// A slice is expanded into the list of values of the condition.
+func (s SelectStatement) Bind(args ...interface{}) (*SelectStatement, error) {
+	if n := s.NumPlaceholder(); n != len(args) {
+		return nil, NewXParserError(ErrMsgBadArgCount, fmt.Sprintf("%d, expected %d", len(args), n))
+	}
+	stmt := s.Clone()
+	err := stmt.bindConditions(func(v string) (arg interface{}, ok bool) {
+		if v != placeholder {
+			return nil, false
+		}
+		arg, args = args[0], args[1:]
+		return arg, true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// Parameters returns the distinct names of the named placeholders, in order of appearance.
+func (s SelectStatement) Parameters() (names []string) {
+	seen := make(map[string]bool)
+	add := func(v string) {
+		if isNamedPlaceholder(v) && !seen[v[1:]] {
+			seen[v[1:]] = true
+			names = append(names, v[1:])
+		}
+	}
+	for _, c := range s.ConditionList() {
+		if val, lit := c.Value(); lit {
+			for _, v := range val {
+				add(v)
+			}
+		}
+	}
+	for _, d := range s.DuringList() {
+		add(d)
+	}
+	return
+}
+
+// BindNamed returns a deep copy of the select statement with its named placeholders
+// replaced by the value of the parameter with the same name.
+// The values of the conditions follow the rules of Bind.
+// In the DURING clause, a parameter can be a date range literal, a date or a list of dates.
+func (s SelectStatement) BindNamed(params map[string]interface{}) (*SelectStatement, error) {
+	names := s.Parameters()
+	for _, name := range names {
+		if _, ok := params[name]; !ok {
+			return nil, NewXParserError(ErrMsgMissingParam, name)
+		}
+	}
+	if len(params) > len(names) {
+		var unknown []string
+		for name := range params {
+			if !s.hasParameter(name) {
+				unknown = append(unknown, name)
+			}
+		}
+		sort.Strings(unknown)
+		return nil, NewXParserError(ErrMsgUnknownParam, strings.Join(unknown, ", "))
+	}
+	stmt := s.Clone()
+	err := stmt.bindConditions(func(v string) (interface{}, bool) {
+		if !isNamedPlaceholder(v) {
+			return nil, false
+		}
+		return params[v[1:]], true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !stmt.hasDuringParameter() {
+		return stmt, nil
+	}
+	var during []string
+	for _, d := range stmt.During {
+		if !isNamedPlaceholder(d) {
+			during = append(during, d)
+			continue
+		}
+		list, _, err := bindArg(params[d[1:]])
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range list {
+			if isDateRangeLiteral(v) {
+				// Stores the canonical form of the keyword.
+				v = strings.ToUpper(v)
+			}
+			during = append(during, v)
+		}
+	}
+	if err := checkDuring(during); err != nil {
+		return nil, err
+	}
+	stmt.During = during
+	return stmt, nil
+}
+
+// hasParameter returns true if the named placeholder is used in the statement.
+func (s SelectStatement) hasParameter(name string) bool {
+	for _, n := range s.Parameters() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDuringParameter returns true if the date range uses a named placeholder.
+func (s SelectStatement) hasDuringParameter() bool {
+	for _, d := range s.During {
+		if isNamedPlaceholder(d) {
+			return true
+		}
+	}
+	return false
+}
+
+// bindConditions replaces the placeholders in the values of the conditions by their argument.
+// The function returns the argument of a value, or false if the value is not a placeholder.
+func (s *SelectStatement) bindConditions(arg func(v string) (interface{}, bool)) error {
+	for i, c := range s.Where {
+		val, lit := c.Value()
+		if !lit {
+			continue
+		}
+		var (
+			bound  bool
+			values = make([]string, 0, len(val))
+		)
+		for _, v := range val {
+			a, ok := arg(v)
+			if !ok {
+				values = append(values, v)
+				continue
+			}
+			list, literal, err := bindArg(a)
+			if err != nil {
+				return err
+			}
+			if len(list) > 1 && !isListOperator(c.OperatorToken()) {
+				return NewXParserError(ErrMsgOperatorValue, c.Operator()+" expects a single value")
+			}
+			values = append(values, list...)
+			bound, lit = true, lit && literal
+		}
+		if bound {
+			s.Where[i] = &Where{
+				Column:         NewColumn(c.Name(), c.Alias()),
+				Sign:           c.Operator(),
+				ColumnValue:    values,
+				IsValueLiteral: lit,
+			}
+		}
+	}
+	return nil
+}
+
+// bindArg returns the values of the argument and whether they are value literals.
+// A slice or an array gives one value by element.
+func bindArg(arg interface{}) (values []string, literal bool, err error) {
+	if _, ok := arg.([]byte); !ok {
+		switch v := reflect.ValueOf(arg); v.Kind() {
+		case reflect.Slice, reflect.Array:
+			if v.Len() == 0 {
+				return nil, false, NewXParserError(ErrMsgBadArgType, fmt.Sprintf("empty %T", arg))
+			}
+			values, literal = make([]string, v.Len()), true
+			for i := range values {
+				var lit bool
+				if values[i], lit, err = bindValue(v.Index(i).Interface()); err != nil {
+					return nil, false, err
+				}
+				literal = literal && lit
+			}
+			return
+		}
+	}
+	value, literal, err := bindValue(arg)
+	if err != nil {
+		return nil, false, err
+	}
+	return []string{value}, literal, nil
+}
+
+// bindValue returns the value of the argument and whether it is a value literal.
+func bindValue(arg interface{}) (string, bool, error) {
+	switch a := arg.(type) {
+	case []byte:
+		return string(a), false, nil
+	case time.Time:
+		return a.Format("20060102"), true, nil
+	}
+	switch v := reflect.ValueOf(arg); v.Kind() {
+	case reflect.String:
+		return v.String(), false, nil
+	case reflect.Bool:
+		if v.Bool() {
+			return "TRUE", true, nil
+		}
+		return "FALSE", true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true, nil
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return strconv.FormatFloat(f, 'f', -1, v.Type().Bits()), true, nil
+		}
+	}
+	return "", false, NewXParserError(ErrMsgBadArgType, fmt.Sprintf("%T", arg))
+}
@@ -0,0 +1,166 @@
+package awqlparse_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestSelectStatement_Bind(t *testing.T) {
+	const q = `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = ? AND CampaignStatus IN [?, ?]`
+	var tests = []struct {
+		args []interface{}
+		str  string
+		err  error
+	}{
+		{
+			args: []interface{}{int64(123), "ENABLED", "PAUSED"},
+			str:  `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = 123 AND CampaignStatus IN [ 'ENABLED' , 'PAUSED' ]`,
+		},
+		{
+			args: []interface{}{uint8(1), []string{"ENABLED", "PAUSED"}, "REMOVED"},
+			str:  `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = 1 AND CampaignStatus IN [ 'ENABLED' , 'PAUSED' , 'REMOVED' ]`,
+		},
+		{
+			args: []interface{}{1.5, true, []int{2, 3}},
+			str:  `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = 1.5 AND CampaignStatus IN [ TRUE , 2 , 3 ]`,
+		},
+		{
+			args: []interface{}{time.Date(2016, 12, 24, 0, 0, 0, 0, time.UTC), 1, "a"},
+			str:  `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = 20161224 AND CampaignStatus IN [ '1' , 'a' ]`,
+		},
+		{args: []interface{}{1, 2}, err: awql.NewXParserError(awql.ErrMsgBadArgCount, "2, expected 3")},
+		{args: []interface{}{[]int{1, 2}, "a", "b"}, err: awql.NewXParserError(awql.ErrMsgOperatorValue, "= expects a single value")},
+		{args: []interface{}{struct{}{}, "a", "b"}, err: awql.NewXParserError(awql.ErrMsgBadArgType, "struct {}")},
+		{args: []interface{}{1, []string{}, "b"}, err: awql.NewXParserError(awql.ErrMsgBadArgType, "empty []string")},
+		{args: []interface{}{nil, "a", "b"}, err: awql.NewXParserError(awql.ErrMsgBadArgType, "<nil>")},
+	}
+	stmt, err := awql.ParseSelectString(q)
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	s := stmt.(*awql.SelectStatement)
+	if n := s.NumPlaceholder(); n != 3 {
+		t.Fatalf("Expected 3 placeholders, received %d", n)
+	}
+	for i, tt := range tests {
+		bs, err := s.Bind(tt.args...)
+		if tt.err != nil {
+			if err == nil || err.Error() != tt.err.Error() {
+				t.Errorf("%d. Expected the error %v, received %v", i, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d. Expected no error, received %v", i, err)
+		} else if str := bs.String(); str != tt.str {
+			t.Errorf("%d. Expected %q, received %q", i, tt.str, str)
+		}
+	}
+	// The source statement is left unchanged.
+	if str := s.String(); str != `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = ? AND CampaignStatus IN [ ? , ? ]` {
+		t.Errorf("Expected the placeholders in the source statement, received %q", str)
+	}
+}
+
+func TestWhere_Placeholders(t *testing.T) {
+	stmt, err := awql.ParseSelectString(`SELECT Cost FROM R WHERE CampaignId IN [1, ?, 3, ?] AND CampaignName = '?'`)
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	conds := stmt.ConditionList()
+	if pos := conds[0].(*awql.Where).Placeholders(); len(pos) != 2 || pos[0] != 1 || pos[1] != 3 {
+		t.Errorf("Expected the placeholders at 1 and 3, received %v", pos)
+	}
+	if pos := conds[1].(*awql.Where).Placeholders(); len(pos) != 0 {
+		t.Errorf("Expected no placeholder in a string, received %v", pos)
+	}
+}
+
+func TestSelectStatement_BindNamed(t *testing.T) {
+	var tests = []struct {
+		q      string
+		params map[string]interface{}
+		str    string
+		err    error
+	}{
+		{
+			q:      `SELECT Cost FROM R WHERE AccountId = @account AND CampaignId IN [:id, @id] DURING @range`,
+			params: map[string]interface{}{"account": 123, "id": 4, "range": "last_7_days"},
+			str:    `SELECT Cost FROM R WHERE AccountId = 123 AND CampaignId IN [ 4 , 4 ] DURING LAST_7_DAYS`,
+		},
+		{
+			q:      `SELECT Cost FROM R DURING @start, @end`,
+			params: map[string]interface{}{"start": time.Date(2016, 12, 24, 0, 0, 0, 0, time.UTC), "end": "20161231"},
+			str:    `SELECT Cost FROM R DURING 20161224,20161231`,
+		},
+		{
+			q:      `SELECT Cost FROM R DURING @range`,
+			params: map[string]interface{}{"range": []int{20161224, 20161231}},
+			str:    `SELECT Cost FROM R DURING 20161224,20161231`,
+		},
+		{
+			q:      `SELECT Cost FROM R WHERE AccountId = @account`,
+			params: map[string]interface{}{},
+			err:    awql.NewXParserError(awql.ErrMsgMissingParam, "account"),
+		},
+		{
+			q:      `SELECT Cost FROM R WHERE AccountId = @account`,
+			params: map[string]interface{}{"account": 1, "id": 2, "day": 3},
+			err:    awql.NewXParserError(awql.ErrMsgUnknownParam, "day, id"),
+		},
+		{
+			q:      `SELECT Cost FROM R DURING @range`,
+			params: map[string]interface{}{"range": "20161224"},
+			err:    awql.NewXParserError(awql.ErrMsgBadDuring, awql.ErrMsgDuringLitSize),
+		},
+		{
+			q:      `SELECT Cost FROM R DURING @start, @end`,
+			params: map[string]interface{}{"start": "20161231", "end": "20161224"},
+			err:    awql.NewXParserError(awql.ErrMsgBadDuring, awql.ErrMsgDuringOrder),
+		},
+		{
+			q:      `SELECT Cost FROM R DURING @start, @end`,
+			params: map[string]interface{}{"start": "YESTERDAY", "end": "rv"},
+			err:    awql.NewXParserError(awql.ErrMsgBadDuring, "rv"),
+		},
+	}
+	for i, tt := range tests {
+		stmt, err := awql.ParseSelectString(tt.q)
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		}
+		bs, err := stmt.(*awql.SelectStatement).BindNamed(tt.params)
+		if tt.err != nil {
+			if err == nil || err.Error() != tt.err.Error() {
+				t.Errorf("%d. Expected the error %v, received %v", i, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d. Expected no error, received %v", i, err)
+		} else if str := bs.String(); str != tt.str {
+			t.Errorf("%d. Expected %q, received %q", i, tt.str, str)
+		}
+	}
+}
+
+func TestSelectStatement_Parameters(t *testing.T) {
+	const q = `SELECT Cost FROM R WHERE CampaignId IN [@id, ?, :account] AND AccountId = @account DURING @start, @end`
+	stmt, err := awql.ParseSelectString(q)
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	names := stmt.(*awql.SelectStatement).Parameters()
+	if exp := []string{"id", "account", "start", "end"}; !reflect.DeepEqual(names, exp) {
+		t.Errorf("Expected %v, received %v", exp, names)
+	}
+	if s := stmt.String(); s != `SELECT Cost FROM R WHERE CampaignId IN [ @id , ? , :account ] AND AccountId = @account DURING @start,@end` {
+		t.Errorf("Expected the placeholders in the output, received %q", s)
+	}
+	if _, err := awql.ParseSelectString(`SELECT Cost FROM R DURING @a, @b, @c`); err == nil {
+		t.Error("Expected an error with three parameters in the date range")
+	}
+}
@@ -0,0 +1,299 @@
+package awqlparse
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SelectBuilder builds a SELECT statement with the same checks as the parser.
+// The first error met is kept and returned by the Build method.
+type SelectBuilder struct {
+	stmt  *SelectStatement
+	group []string
+	order []orderExpr
+	err   error
+}
+
+// orderExpr represents a column to sort on, by name, alias or position.
+type orderExpr struct {
+	expr string
+	desc bool
+}
+
+// NewSelect returns a builder of a SELECT statement on the given table.
+func NewSelect(table string) *SelectBuilder {
+	b := &SelectBuilder{stmt: &SelectStatement{}}
+	if !isIdentifier(table) {
+		b.err = NewXParserError(ErrMsgBadSrc, table)
+	}
+	b.stmt.TableName = table
+	return b
+}
+
+// Columns adds the columns to the selected fields.
+func (b *SelectBuilder) Columns(names ...string) *SelectBuilder {
+	for _, name := range names {
+		if name != "*" && !isIdentifier(name) {
+			b.setErr(NewXParserError(ErrMsgBadField, name))
+		}
+		b.stmt.Fields = append(b.stmt.Fields, NewDynamicColumn(NewColumn(name, ""), "", false))
+	}
+	return b
+}
+
+// Aggregate adds a field using the aggregate function on the column, with an optional alias.
+func (b *SelectBuilder) Aggregate(method, name, alias string) *SelectBuilder {
+	method = strings.ToUpper(method)
+	switch {
+	case !isFunction(method):
+		b.setErr(NewXParserError(ErrMsgBadFunc, method))
+	case name == "*" && method != "COUNT":
+		// Accept the rune '*' only with the count function.
+		b.setErr(NewXParserError(ErrMsgSyntax, name))
+	case name != "*" && !isIdentifier(name):
+		b.setErr(NewXParserError(ErrMsgBadField, name))
+	case alias != "" && !isIdentifier(alias):
+		b.setErr(NewXParserError(ErrMsgBadField, alias))
+	}
+	b.stmt.Fields = append(b.stmt.Fields, NewDynamicColumn(NewColumn(name, alias), method, false))
+	return b
+}
+
+// Where adds a condition on the column, the values are strings.
+func (b *SelectBuilder) Where(name, operator string, values ...string) *SelectBuilder {
+	return b.where(name, operator, values, false)
+}
+
+// WhereLiteral adds a condition on the column, the values are literals, as numbers.
+func (b *SelectBuilder) WhereLiteral(name, operator string, values ...string) *SelectBuilder {
+	return b.where(name, operator, values, true)
+}
+
+// where adds a condition on the column.
+func (b *SelectBuilder) where(name, operator string, values []string, literal bool) *SelectBuilder {
+	b.setErr(b.stmt.AndWhere(name, operator, values, literal))
+	return b
+}
+
+// During sets the date range: a date range literal or two dates formatted as YYYYMMDD.
+func (b *SelectBuilder) During(dates ...string) *SelectBuilder {
+	b.setErr(b.stmt.SetDuring(dates...))
+	return b
+}
+
+// GroupBy adds the columns to group by, with their name, alias or position.
+func (b *SelectBuilder) GroupBy(exprs ...string) *SelectBuilder {
+	b.group = append(b.group, exprs...)
+	return b
+}
+
+// OrderBy adds a column to sort on, with its name or alias.
+func (b *SelectBuilder) OrderBy(expr string, desc bool) *SelectBuilder {
+	b.order = append(b.order, orderExpr{expr: expr, desc: desc})
+	return b
+}
+
+// OrderByPos adds a column to sort on, with its position in the selected fields.
+func (b *SelectBuilder) OrderByPos(pos int, desc bool) *SelectBuilder {
+	return b.OrderBy(strconv.Itoa(pos), desc)
+}
+
+// Limit sets the start index and the row count.
+func (b *SelectBuilder) Limit(offset, rowCount int) *SelectBuilder {
+	b.setErr(b.stmt.SetLimit(offset, rowCount))
+	return b
+}
+
+// Build returns the select statement or the first error met.
+// The columns of the GROUP BY and ORDER BY clauses are resolved with the selected fields.
+// Each call returns a new statement, sharing nothing with the builder.
+func (b *SelectBuilder) Build() (*SelectStatement, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.stmt.Fields) == 0 {
+		return nil, NewXParserError(ErrMsgBadField, "")
+	}
+	// Deep copy, so as the builder can be used again without altering the built statements.
+	stmt := b.stmt.Clone()
+	for _, expr := range b.group {
+		groupBy, err := stmt.searchColumn(expr)
+		if err != nil {
+			return nil, wrapParserError(ErrMsgBadGroup, err)
+		}
+		stmt.GroupBy = append(stmt.GroupBy, groupBy)
+	}
+	for _, o := range b.order {
+		if err := stmt.AddOrderBy(o.expr, o.desc); err != nil {
+			return nil, err
+		}
+	}
+	return stmt, nil
+}
+
+// setErr keeps the first error.
+func (b *SelectBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// DescribeBuilder builds a DESC statement.
+type DescribeBuilder struct {
+	stmt *DescribeStatement
+	err  error
+}
+
+// NewDescribe returns a builder of a DESC statement on the given table.
+func NewDescribe(table string) *DescribeBuilder {
+	b := &DescribeBuilder{stmt: &DescribeStatement{}}
+	if !isIdentifier(table) {
+		b.err = NewXParserError(ErrMsgBadSrc, table)
+	}
+	b.stmt.TableName = table
+	return b
+}
+
+// Full enables the full mode.
+func (b *DescribeBuilder) Full() *DescribeBuilder {
+	b.stmt.Full = true
+	return b
+}
+
+// Column restricts the description to this column.
+func (b *DescribeBuilder) Column(name string) *DescribeBuilder {
+	if !isIdentifier(name) && b.err == nil {
+		b.err = NewXParserError(ErrMsgBadField, name)
+	}
+	b.stmt.Fields = []DynamicField{NewDynamicColumn(NewColumn(name, ""), "", false)}
+	return b
+}
+
+// Build returns the describe statement or the first error met.
+func (b *DescribeBuilder) Build() (*DescribeStatement, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	stmt := *b.stmt
+	return &stmt, nil
+}
+
+// ShowBuilder builds a SHOW TABLES statement.
+type ShowBuilder struct {
+	stmt *ShowStatement
+	like bool
+}
+
+// NewShow returns a builder of a SHOW TABLES statement.
+func NewShow() *ShowBuilder {
+	return &ShowBuilder{stmt: &ShowStatement{}}
+}
+
+// Full enables the full mode.
+func (b *ShowBuilder) Full() *ShowBuilder {
+	b.stmt.Full = true
+	return b
+}
+
+// Like filters the tables by their name, the pattern can use the wildcard %.
+func (b *ShowBuilder) Like(pattern string) *ShowBuilder {
+	b.stmt.Like = newPattern(pattern)
+	b.like = true
+	return b
+}
+
+// With filters the tables having this column.
+func (b *ShowBuilder) With(name string) *ShowBuilder {
+	b.stmt.With = name
+	b.stmt.UseWith = true
+	return b
+}
+
+// Build returns the show statement or an error if both LIKE and WITH clauses are used.
+func (b *ShowBuilder) Build() (*ShowStatement, error) {
+	if b.like && b.stmt.UseWith {
+		return nil, NewXParserError(ErrMsgSyntax, "WITH")
+	}
+	stmt := *b.stmt
+	return &stmt, nil
+}
+
+// CreateViewBuilder builds a CREATE VIEW statement.
+type CreateViewBuilder struct {
+	stmt *CreateViewStatement
+	err  error
+}
+
+// NewCreateView returns a builder of a CREATE VIEW statement with the given name.
+func NewCreateView(name string) *CreateViewBuilder {
+	b := &CreateViewBuilder{stmt: &CreateViewStatement{}}
+	if !isIdentifier(name) {
+		b.err = NewXParserError(ErrMsgBadSrc, name)
+	}
+	b.stmt.TableName = name
+	return b
+}
+
+// OrReplace enables the replacement of the existing view.
+func (b *CreateViewBuilder) OrReplace() *CreateViewBuilder {
+	b.stmt.Replace = true
+	return b
+}
+
+// Columns names the columns of the view.
+func (b *CreateViewBuilder) Columns(names ...string) *CreateViewBuilder {
+	for _, name := range names {
+		if !isIdentifier(name) && b.err == nil {
+			b.err = NewXParserError(ErrMsgBadField, name)
+		}
+		b.stmt.Fields = append(b.stmt.Fields, NewDynamicColumn(NewColumn(name, ""), "", false))
+	}
+	return b
+}
+
+// As sets the source query of the view.
+func (b *CreateViewBuilder) As(view *SelectStatement) *CreateViewBuilder {
+	b.stmt.View = view
+	return b
+}
+
+// Build returns the create view statement or the first error met.
+// The number of columns of the view must match the ones of its source query.
+func (b *CreateViewBuilder) Build() (*CreateViewStatement, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.stmt.View == nil {
+		return nil, NewParserError(ErrMsgMissingSrc)
+	}
+	if vcs := len(b.stmt.Fields); vcs > 0 && vcs != len(b.stmt.View.Fields) {
+		return nil, NewParserError(ErrMsgColumnsNotMatch)
+	}
+	stmt := *b.stmt
+	return &stmt, nil
+}
+
+// isOperatorString returns true if the string is scanned as one operator.
+func isOperatorString(s string) bool {
+	return operatorToken(s) != ILLEGAL
+}
+
+// operatorToken returns the token of the operator, whatever its case.
+// It returns ILLEGAL if the string is not scanned as one operator.
+func operatorToken(s string) Token {
+	sc := NewScanner(strings.NewReader(s))
+	if tk, literal := sc.Scan(); isOperator(tk) && literal == s {
+		return tk
+	}
+	return ILLEGAL
+}
+
+// isValueLiteralString returns true if the string is scanned as one value literal.
+func isValueLiteralString(s string) bool {
+	sc := NewScanner(strings.NewReader(s))
+	switch tk, literal := sc.Scan(); tk {
+	case DECIMAL, DIGIT, VALUE_LITERAL, IDENTIFIER:
+		return literal == s
+	}
+	return false
+}
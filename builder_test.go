@@ -0,0 +1,142 @@
+package awqlparse_test
+
+import (
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestSelectBuilder_Build(t *testing.T) {
+	var tests = []struct {
+		b   *awql.SelectBuilder
+		q   string
+		err bool
+	}{
+		{
+			b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").
+				Columns("CampaignName").
+				Aggregate("sum", "Cost", "total").
+				Where("CampaignStatus", "=", "ENABLED").
+				During("LAST_7_DAYS").
+				OrderByPos(2, true).
+				Limit(0, 10),
+			q: "SELECT CampaignName, SUM(Cost) AS total FROM CAMPAIGN_PERFORMANCE_REPORT " +
+				"WHERE CampaignStatus = 'ENABLED' DURING LAST_7_DAYS ORDER BY 2 DESC LIMIT 10",
+		},
+		{
+			b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").
+				OrderBy("total", false).
+				GroupBy("CampaignId").
+				Columns("CampaignId").
+				Aggregate("COUNT", "*", "total").
+				WhereLiteral("Clicks", ">", "10").
+				WhereLiteral("CampaignId", "IN", "1", "2").
+				During("20161224", "20161225").
+				Limit(5, 10),
+			q: "SELECT CampaignId, COUNT(*) AS total FROM CAMPAIGN_PERFORMANCE_REPORT " +
+				"WHERE Clicks > 10 AND CampaignId IN [ 1 , 2 ] DURING 20161224,20161225 GROUP BY 1 ORDER BY 2 LIMIT 5, 10",
+		},
+		{
+			b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").
+				Columns("Date", "Cost").
+				Where("Date", "IN", "2016-12-24", "2016-12-25").
+				Where("Day", "=", "2016-12-24"),
+			q: "SELECT Date, Cost FROM CAMPAIGN_PERFORMANCE_REPORT " +
+				"WHERE Date IN [ 20161224 , 20161225 ] AND Day = '2016-12-24'",
+		},
+		{b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT"), err: true},
+		{b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").Columns("CampaignId").WhereLiteral("Clicks", ">", "1", "2"), err: true},
+		{b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").Columns("CampaignId").Where("CampaignName", "CONTAINS", "a", "b"), err: true},
+		{b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").Columns("Date").Where("Date", "=", "2016-02-30"), err: true},
+		{b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").Columns("Date").Where("Date", "IN", "2016-12-24", "2016-13-01"), err: true},
+		{b: awql.NewSelect("").Columns("CampaignId"), err: true},
+		{b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").Aggregate("NOP", "Cost", ""), err: true},
+		{b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").Aggregate("SUM", "*", ""), err: true},
+		{b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").Columns("CampaignId").Where("Cost", "~", "1"), err: true},
+		{b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").Columns("CampaignId").During("20161224"), err: true},
+		{b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").Columns("CampaignId").During("TODAY", "20161224"), err: true},
+		{b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").Columns("CampaignId").OrderByPos(2, false), err: true},
+		{b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").Columns("CampaignId").GroupBy("Cost"), err: true},
+		{b: awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").Columns("CampaignId").Limit(0, -1), err: true},
+	}
+
+	for i, tt := range tests {
+		stmt, err := tt.b.Build()
+		if tt.err {
+			if err == nil {
+				t.Errorf("%d. Expected an error, received %q", i, stmt.String())
+			}
+		} else if err != nil {
+			t.Errorf("%d. Expected no error, received %v", i, err)
+		} else if q := stmt.String(); q != tt.q {
+			t.Errorf("%d. Expected %q, received %q", i, tt.q, q)
+		}
+	}
+}
+
+func TestSelectBuilder_BuildTwice(t *testing.T) {
+	b := awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").
+		Columns("CampaignId", "CampaignName").
+		Where("CampaignStatus", "IN", "ENABLED", "PAUSED").
+		During("20161224", "20161225").
+		GroupBy("CampaignId").
+		OrderBy("CampaignName", true)
+	s1, err := b.Build()
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	q := s1.String()
+	// Changes on the first statement must not alter the builder.
+	s1.Fields[0].(*awql.DynamicColumn).ColumnName = "AdGroupId"
+	s1.Where[0].(*awql.Where).ColumnValue[0] = "REMOVED"
+	s1.During[0] = "20170101"
+	s2, err := b.Limit(0, 5).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	if s := s2.String(); s != q+" LIMIT 5" {
+		t.Errorf("Expected %q, received %q", q+" LIMIT 5", s)
+	}
+	if len(s2.GroupBy) != 1 || len(s2.OrderBy) != 1 {
+		t.Errorf("Expected one group and one order, received %d and %d", len(s2.GroupBy), len(s2.OrderBy))
+	}
+	if s1.RowCount != 0 {
+		t.Errorf("Expected no limit on the first statement, received %d", s1.RowCount)
+	}
+}
+
+func TestOtherBuilders(t *testing.T) {
+	desc, err := awql.NewDescribe("CAMPAIGN_PERFORMANCE_REPORT").Full().Column("CampaignId").Build()
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	if q := desc.String(); q != "DESC FULL CAMPAIGN_PERFORMANCE_REPORT CampaignId" {
+		t.Errorf("Unexpected describe statement, received %q", q)
+	}
+
+	show, err := awql.NewShow().Full().Like("CAMPAIGN%").Build()
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	if q := show.String(); q != "SHOW FULL TABLES LIKE 'CAMPAIGN%'" {
+		t.Errorf("Unexpected show statement, received %q", q)
+	}
+	if _, err := awql.NewShow().Like("CAMPAIGN%").With("CampaignId").Build(); err == nil {
+		t.Error("Expected an error with both like and with clauses")
+	}
+
+	view, err := awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").Columns("CampaignId", "Clicks").Build()
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	cv, err := awql.NewCreateView("rv").OrReplace().Columns("id", "clicks").As(view).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	if q := cv.String(); q != "CREATE OR REPLACE VIEW rv (id, clicks) AS SELECT CampaignId, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT" {
+		t.Errorf("Unexpected create view statement, received %q", q)
+	}
+	if _, err := awql.NewCreateView("rv").Columns("id").As(view).Build(); err == nil {
+		t.Error("Expected an error with a wrong number of columns")
+	}
+}
@@ -0,0 +1,162 @@
+package awqlparse
+
+// cloner deep copies the parts of a statement.
+// It keeps the columns shared between the clauses of the statement shared in the copy.
+type cloner map[*Column]*Column
+
+// column returns the copy of the column.
+func (c cloner) column(col *Column) *Column {
+	if col == nil {
+		return nil
+	}
+	if cc, ok := c[col]; ok {
+		return cc
+	}
+	cc := *col
+	c[col] = &cc
+	return &cc
+}
+
+// columnPosition returns the copy of the column position.
+func (c cloner) columnPosition(col *ColumnPosition) *ColumnPosition {
+	if col == nil {
+		return nil
+	}
+	return &ColumnPosition{Column: c.column(col.Column), ColumnPos: col.ColumnPos}
+}
+
+// fields returns the copy of the fields.
+// Fields of unknown type are kept as is.
+func (c cloner) fields(list []DynamicField) []DynamicField {
+	if list == nil {
+		return nil
+	}
+	cl := make([]DynamicField, len(list))
+	for i, f := range list {
+		if fc, ok := f.(*DynamicColumn); ok && fc != nil {
+			f = &DynamicColumn{Column: c.column(fc.Column), Method: fc.Method, Unique: fc.Unique}
+		}
+		cl[i] = f
+	}
+	return cl
+}
+
+// selectStatement returns the copy of the select statement.
+func (c cloner) selectStatement(s SelectStatement) *SelectStatement {
+	s.Fields = c.fields(s.Fields)
+	if s.Excluded != nil {
+		excluded := make([]Field, len(s.Excluded))
+		for i, e := range s.Excluded {
+			if ec, ok := e.(*Column); ok && ec != nil {
+				e = c.column(ec)
+			}
+			excluded[i] = e
+		}
+		s.Excluded = excluded
+	}
+	s.During = copyStrings(s.During)
+	if s.Where != nil {
+		where := make([]Condition, len(s.Where))
+		for i, w := range s.Where {
+			if wc, ok := w.(*Where); ok && wc != nil {
+				w = &Where{
+					Column:         c.column(wc.Column),
+					Sign:           wc.Sign,
+					ColumnValue:    copyStrings(wc.ColumnValue),
+					IsValueLiteral: wc.IsValueLiteral,
+				}
+			}
+			where[i] = w
+		}
+		s.Where = where
+	}
+	if s.GroupBy != nil {
+		group := make([]FieldPosition, len(s.GroupBy))
+		for i, g := range s.GroupBy {
+			if gc, ok := g.(*ColumnPosition); ok && gc != nil {
+				g = c.columnPosition(gc)
+			}
+			group[i] = g
+		}
+		s.GroupBy = group
+	}
+	if s.OrderBy != nil {
+		order := make([]Orderer, len(s.OrderBy))
+		for i, o := range s.OrderBy {
+			if oc, ok := o.(*Order); ok && oc != nil {
+				o = &Order{ColumnPosition: c.columnPosition(oc.ColumnPosition), SortDesc: oc.SortDesc, SortAsc: oc.SortAsc, Index: oc.Index}
+			}
+			order[i] = o
+		}
+		s.OrderBy = order
+	}
+	return &s
+}
+
+// copyStrings returns a copy of the list.
+func copyStrings(list []string) []string {
+	if list == nil {
+		return nil
+	}
+	return append([]string(nil), list...)
+}
+
+// Clone returns a deep copy of the create view statement, with its source query.
+func (s CreateViewStatement) Clone() *CreateViewStatement {
+	c := make(cloner)
+	s.Fields = c.fields(s.Fields)
+	if s.View != nil {
+		s.View = c.selectStatement(*s.View)
+	}
+	return &s
+}
+
+// Clone returns a deep copy of the describe statement.
+func (s DescribeStatement) Clone() *DescribeStatement {
+	s.Fields = make(cloner).fields(s.Fields)
+	return &s
+}
+
+// Clone returns a deep copy of the select statement.
+// The columns shared by the fields and the GROUP BY or ORDER BY clauses stay shared in the copy.
+func (s SelectStatement) Clone() *SelectStatement {
+	return make(cloner).selectStatement(s)
+}
+
+// Clone returns a copy of the show statement.
+func (s ShowStatement) Clone() *ShowStatement {
+	return &s
+}
+
+// Clone returns a copy of the use statement.
+func (s UseStatement) Clone() *UseStatement {
+	return &s
+}
+
+// CloneStmt returns a deep copy of the statement.
+// A statement of unknown type is returned as is.
+func CloneStmt(stmt Stmt) Stmt {
+	switch s := stmt.(type) {
+	case *CreateViewStatement:
+		return s.Clone()
+	case *DescribeStatement:
+		return s.Clone()
+	case *SelectStatement:
+		return s.Clone()
+	case *ShowStatement:
+		return s.Clone()
+	case *UseStatement:
+		return s.Clone()
+	case CreateViewStatement:
+		return s.Clone()
+	case DescribeStatement:
+		return s.Clone()
+	case SelectStatement:
+		return s.Clone()
+	case ShowStatement:
+		return s.Clone()
+	case UseStatement:
+		return s.Clone()
+	}
+	return stmt
+}
@@ -0,0 +1,85 @@
+package awqlparse_test
+
+import (
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestSelectStatement_Clone(t *testing.T) {
+	q := `SELECT CampaignId, CampaignName, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus IN ["ENABLED","PAUSED"] DURING 20161224,20161225 GROUP BY 1 ORDER BY 2 DESC LIMIT 5`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	src := stmts[0].(*awql.SelectStatement)
+	orig := src.String()
+
+	clone := awql.CloneStmt(src).(*awql.SelectStatement)
+	if s := clone.String(); s != orig {
+		t.Fatalf("Expected %q as clone, received %q", orig, s)
+	}
+	clone.TableName = "ADGROUP_PERFORMANCE_REPORT"
+	clone.Fields[0].(*awql.DynamicColumn).ColumnName = "AdGroupId"
+	clone.Fields[1].(*awql.DynamicColumn).ColumnAlias = "name"
+	clone.Where[0].(*awql.Where).ColumnValue[0] = "REMOVED"
+	clone.During[0] = "20161223"
+	clone.GroupBy[0].(*awql.ColumnPosition).ColumnPos = 3
+	clone.OrderBy[0].(*awql.Order).SortDesc = false
+	if s := src.String(); s != orig {
+		t.Errorf("Expected the source unchanged %q, received %q", orig, s)
+	}
+
+	// Columns shared by the clauses stay shared in the clone.
+	if name := clone.OrderBy[0].Alias(); name != "name" {
+		t.Errorf("Expected the alias of the field in the order by clause, received %q", name)
+	}
+	if name := clone.GroupBy[0].Name(); name != "AdGroupId" {
+		t.Errorf("Expected the name of the field in the group by clause, received %q", name)
+	}
+}
+
+func TestCreateViewStatement_Clone(t *testing.T) {
+	q := `CREATE VIEW rv (id, name) AS SELECT CampaignId, CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId > 1`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	src := stmts[0].(*awql.CreateViewStatement)
+	orig := src.String()
+
+	clone := src.Clone()
+	clone.Fields[0].(*awql.DynamicColumn).ColumnName = "cid"
+	clone.View.TableName = "ADGROUP_PERFORMANCE_REPORT"
+	clone.View.Where[0].(*awql.Where).ColumnValue[0] = "2"
+	if s := src.String(); s != orig {
+		t.Errorf("Expected the source unchanged %q, received %q", orig, s)
+	}
+}
+
+func TestCloneStmt(t *testing.T) {
+	q := `DESC CAMPAIGN_PERFORMANCE_REPORT CampaignId;SHOW TABLES LIKE "C%R%";USE 123-456-7890`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	for i, stmt := range stmts {
+		orig := stmt.String()
+		clone := awql.CloneStmt(stmt)
+		if s := clone.String(); s != orig {
+			t.Errorf("%d. Expected %q as clone, received %q", i, orig, s)
+		}
+		switch c := clone.(type) {
+		case *awql.DescribeStatement:
+			c.Fields[0].(*awql.DynamicColumn).ColumnName = "CampaignName"
+		case *awql.ShowStatement:
+			c.Like.Expr = "A%B"
+		case *awql.UseStatement:
+			c.Account = "1"
+		}
+		if s := stmt.String(); s != orig {
+			t.Errorf("%d. Expected the source unchanged %q, received %q", i, orig, s)
+		}
+	}
+}
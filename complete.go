@@ -0,0 +1,338 @@
+package awqlparse
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// SuggestionKind represents the kind of a candidate to complete a query.
+type SuggestionKind int
+
+// List of kinds of candidate.
+const (
+	KeywordSuggestion SuggestionKind = iota
+	OperatorSuggestion
+	FunctionSuggestion
+	DateRangeSuggestion
+	TableSuggestion
+	ColumnSuggestion
+)
+
+// Suggestion represents a candidate to complete a query at the cursor position.
+// Prefix is the part of the word already typed before the cursor, to replace by the text.
+type Suggestion struct {
+	Text   string
+	Kind   SuggestionKind
+	Prefix string
+}
+
+// Completer suggests what can legally come next in a query.
+// Tables and Columns are optional hooks used to list the names of tables
+// and the names of the columns of a table, the table can be unknown.
+type Completer struct {
+	Tables  func() []string
+	Columns func(table string) []string
+}
+
+// Complete returns the candidates to complete the query at the cursor position,
+// without table or column names. See Completer.Complete.
+func Complete(query string, cursor int) ([]Suggestion, error) {
+	return Completer{}.Complete(query, cursor)
+}
+
+// Complete returns the candidates to complete the query at the cursor position, a byte offset.
+// If the cursor is at the end of a partially typed word, the candidates are the ones beginning
+// with it, whatever their case, and this word is returned as prefix of each suggestion.
+func (c Completer) Complete(query string, cursor int) ([]Suggestion, error) {
+	if cursor < 0 || cursor > len(query) {
+		return nil, NewXParserError(ErrMsgBadCursor, cursor)
+	}
+	// Only the tokens of the current statement are required.
+	before := scanTokens(query[:cursor])
+	start, nb := 0, 0
+	for i, t := range before {
+		if isTerminator(t.tk) {
+			start, nb = i+1, nb+1
+		}
+	}
+	before = before[start:]
+	// Ignores the last word if it is being typed.
+	var prefix string
+	if n := len(before); n > 0 {
+		switch last := before[n-1]; {
+		case last.tk == ILLEGAL || last.tk == UNTERMINATED_STRING:
+			// Inside a string or after an invalid character.
+			return nil, nil
+		case isValueLiteral(lastRune(query[:cursor])):
+			prefix, before = last.lit, before[:n-1]
+		}
+	}
+	before = withoutSpaces(before)
+
+	// Lists the candidates, only the ones beginning with the prefix are kept.
+	var list []Suggestion
+	for _, s := range c.suggest(before, statementTable(query, nb)) {
+		if strings.HasPrefix(strings.ToUpper(s.Text), strings.ToUpper(prefix)) {
+			s.Prefix = prefix
+			list = append(list, s)
+		}
+	}
+	return list, nil
+}
+
+// lastRune returns the last rune of the string.
+func lastRune(s string) rune {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r
+}
+
+// scanned represents a token with its literal.
+type scanned struct {
+	tk  Token
+	lit string
+}
+
+// scanTokens returns all the tokens of the query.
+func scanTokens(query string) []scanned {
+	var list []scanned
+	s := NewScanner(strings.NewReader(query))
+	for {
+		tk, lit := s.Scan()
+		if tk == EOF {
+			return list
+		}
+		list = append(list, scanned{tk: tk, lit: lit})
+	}
+}
+
+// withoutSpaces returns the tokens without the white spaces.
+func withoutSpaces(list []scanned) []scanned {
+	var res []scanned
+	for _, t := range list {
+		if t.tk != WHITE_SPACE {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// isTerminator returns true if the token ends a statement.
+func isTerminator(tk Token) bool {
+	return tk == SEMICOLON || tk == G_MODIFIER
+}
+
+// statementTable returns the name of the table used in the FROM clause
+// of the statement at this index or an empty string.
+func statementTable(query string, index int) string {
+	list := withoutSpaces(scanTokens(query))
+	for i, t := range list {
+		switch {
+		case isTerminator(t.tk):
+			index--
+		case index == 0 && t.tk == FROM && i+1 < len(list) && list[i+1].tk == IDENTIFIER:
+			return list[i+1].lit
+		}
+	}
+	return ""
+}
+
+// suggest returns the candidates to follow the tokens of the statement.
+func (c Completer) suggest(list []scanned, table string) []Suggestion {
+	if len(list) == 0 {
+		return keywordSuggestions(statementNames...)
+	}
+	last := list[len(list)-1]
+	switch list[0].tk {
+	case SELECT:
+		return c.selectSuggestions(list, table)
+	case DESC, DESCRIBE:
+		switch {
+		case len(list) == 1:
+			return append(keywordSuggestions("FULL"), c.tables()...)
+		case last.tk == FULL:
+			return c.tables()
+		case last.tk == IDENTIFIER && (len(list) == 2 || (len(list) == 3 && list[1].tk == FULL)):
+			return c.columns(last.lit)
+		}
+	case SHOW:
+		switch last.tk {
+		case SHOW:
+			return keywordSuggestions("FULL", "TABLES")
+		case FULL:
+			return keywordSuggestions("TABLES")
+		case TABLES:
+			return keywordSuggestions("LIKE", "WITH")
+		case WITH:
+			return c.columns("")
+		}
+	case CREATE:
+		for i, t := range list {
+			if t.tk == SELECT {
+				return c.selectSuggestions(list[i:], table)
+			}
+		}
+		switch last.tk {
+		case CREATE:
+			return keywordSuggestions("OR REPLACE", "VIEW")
+		case OR:
+			return keywordSuggestions("REPLACE")
+		case REPLACE:
+			return keywordSuggestions("VIEW")
+		case AS:
+			return keywordSuggestions("SELECT")
+		case IDENTIFIER, RIGHT_PARENTHESIS:
+			if prev := list[len(list)-2]; prev.tk == VIEW || last.tk == RIGHT_PARENTHESIS {
+				return keywordSuggestions("AS")
+			}
+		}
+	}
+	return nil
+}
+
+// selectSuggestions returns the candidates to follow the tokens of a SELECT statement.
+func (c Completer) selectSuggestions(list []scanned, table string) []Suggestion {
+	// Retrieves the current clause.
+	clause, start := SELECT, 0
+	for i, t := range list {
+		if _, ok := clauseRanks[t.tk]; ok || t.tk == FROM {
+			clause, start = t.tk, i
+		}
+	}
+	last, prev := list[len(list)-1], scanned{}
+	if len(list) > 1 {
+		prev = list[len(list)-2]
+	}
+	switch clause {
+	case SELECT:
+		switch last.tk {
+		case SELECT:
+			return append(append(keywordSuggestions("DISTINCT"), c.columns(table)...), functionSuggestions()...)
+		case COMMA:
+			return append(c.columns(table), functionSuggestions()...)
+		case LEFT_PARENTHESIS:
+			return append(keywordSuggestions("DISTINCT"), c.columns(table)...)
+		case DISTINCT:
+			return c.columns(table)
+		case IDENTIFIER, RIGHT_PARENTHESIS, ASTERISK:
+			if prev.tk == AS {
+				return keywordSuggestions("FROM")
+			}
+			if last.tk == ASTERISK && prev.tk == SELECT {
+				return keywordSuggestions("EXCEPT", "FROM")
+			}
+			if last.tk == IDENTIFIER && prev.tk == LEFT_PARENTHESIS {
+				// Column of an aggregate function.
+				return nil
+			}
+			return keywordSuggestions("AS", "FROM")
+		}
+	case FROM:
+		if last.tk == FROM {
+			return c.tables()
+		}
+		return nextClauses(FROM)
+	case WHERE:
+		var open bool
+		for _, t := range list[start:] {
+			switch t.tk {
+			case LEFT_SQUARE_BRACKETS:
+				open = true
+			case RIGHT_SQUARE_BRACKETS:
+				open = false
+			}
+		}
+		switch {
+		case open:
+			// Inside a list of values.
+			return nil
+		case last.tk == WHERE || last.tk == AND:
+			return c.columns(table)
+		case last.tk == IDENTIFIER && (prev.tk == WHERE || prev.tk == AND):
+			return operatorSuggestions()
+		case isOperator(last.tk):
+			// A value is expected.
+			return nil
+		}
+		return append(keywordSuggestions("AND"), nextClauses(WHERE)...)
+	case DURING:
+		if last.tk == DURING || last.tk == COMMA {
+			return dateRangeSuggestions()
+		}
+		return nextClauses(DURING)
+	case GROUP, ORDER:
+		switch last.tk {
+		case GROUP, ORDER:
+			return keywordSuggestions("BY")
+		case BY, COMMA:
+			return c.columns(table)
+		case ASC, DESC:
+			return nextClauses(clause)
+		case WITH:
+			return keywordSuggestions("ROLLUP")
+		}
+		if prev.tk == WITH && isRollup(last.tk, last.lit) {
+			return nextClauses(clause)
+		}
+		if clause == ORDER {
+			return append(keywordSuggestions("ASC", "DESC"), nextClauses(ORDER)...)
+		}
+		return append(keywordSuggestions("WITH ROLLUP"), nextClauses(GROUP)...)
+	}
+	return nil
+}
+
+// tables returns the names of the tables given by the hook.
+func (c Completer) tables() []Suggestion {
+	if c.Tables == nil {
+		return nil
+	}
+	return suggestions(TableSuggestion, c.Tables()...)
+}
+
+// columns returns the names of the columns of the table given by the hook.
+func (c Completer) columns(table string) []Suggestion {
+	if c.Columns == nil {
+		return nil
+	}
+	return suggestions(ColumnSuggestion, c.Columns(table)...)
+}
+
+// nextClauses returns the clauses which can follow this one.
+func nextClauses(clause Token) []Suggestion {
+	var list []Suggestion
+	for _, tk := range []Token{WHERE, DURING, GROUP, ORDER, LIMIT} {
+		if clauseRanks[tk] > clauseRanks[clause] {
+			list = append(list, Suggestion{Text: clauseNames[tk], Kind: KeywordSuggestion})
+		}
+	}
+	return list
+}
+
+// keywordSuggestions returns the keywords as candidates.
+func keywordSuggestions(names ...string) []Suggestion {
+	return suggestions(KeywordSuggestion, names...)
+}
+
+// operatorSuggestions returns the operators as candidates.
+func operatorSuggestions() []Suggestion {
+	return suggestions(OperatorSuggestion, Operators()...)
+}
+
+// functionSuggestions returns the aggregate functions as candidates.
+func functionSuggestions() []Suggestion {
+	return suggestions(FunctionSuggestion, Functions()...)
+}
+
+// dateRangeSuggestions returns the date range literals as candidates.
+func dateRangeSuggestions() []Suggestion {
+	return suggestions(DateRangeSuggestion, DateRangeLiterals()...)
+}
+
+// suggestions returns the texts as candidates of this kind.
+func suggestions(kind SuggestionKind, texts ...string) []Suggestion {
+	list := make([]Suggestion, len(texts))
+	for i, t := range texts {
+		list[i] = Suggestion{Text: t, Kind: kind}
+	}
+	return list
+}
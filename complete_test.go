@@ -0,0 +1,90 @@
+package awqlparse_test
+
+import (
+	"reflect"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestCompleter_Complete(t *testing.T) {
+	c := awql.Completer{
+		Tables: func() []string {
+			return []string{"ADGROUP_PERFORMANCE_REPORT", "CAMPAIGN_PERFORMANCE_REPORT"}
+		},
+		Columns: func(table string) []string {
+			if table == "ADGROUP_PERFORMANCE_REPORT" {
+				return []string{"AdGroupId", "AdGroupName"}
+			}
+			return []string{"CampaignId", "CampaignName", "Clicks", "Cost"}
+		},
+	}
+	var tests = []struct {
+		q      string
+		cursor int
+		texts  []string
+		prefix string
+	}{
+		{q: ``, texts: []string{"CREATE", "DESC", "DESCRIBE", "SELECT", "SHOW", "USE"}},
+		{q: `sel`, texts: []string{"SELECT"}, prefix: "sel"},
+		{q: `SELECT C`, texts: []string{"CampaignId", "CampaignName", "Clicks", "Cost", "COUNT"}, prefix: "C"},
+		{q: `SELECT Ad FROM ADGROUP_PERFORMANCE_REPORT`, cursor: 9, texts: []string{"AdGroupId", "AdGroupName"}, prefix: "Ad"},
+		{q: `SELECT Cost `, texts: []string{"AS", "FROM"}},
+		{q: `SELECT * `, texts: []string{"EXCEPT", "FROM"}},
+		{q: `SELECT Cost FROM `, texts: []string{"ADGROUP_PERFORMANCE_REPORT", "CAMPAIGN_PERFORMANCE_REPORT"}},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT `, texts: []string{"WHERE", "DURING", "GROUP BY", "ORDER BY", "LIMIT"}},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Cl`, texts: []string{"Clicks"}, prefix: "Cl"},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks con`, texts: []string{"CONTAINS", "CONTAINS_IGNORE_CASE"}, prefix: "con"},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > `},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks IN [1, `},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName = 'rv`},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0 `, texts: []string{"AND", "DURING", "GROUP BY", "ORDER BY", "LIMIT"}},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT DURING LAST_7`, texts: []string{"LAST_7_DAYS"}, prefix: "LAST_7"},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT ORDER `, texts: []string{"BY"}},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT ORDER BY Cost `, texts: []string{"ASC", "DESC", "LIMIT"}},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY Cost `, texts: []string{"WITH ROLLUP", "ORDER BY", "LIMIT"}},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY Cost WITH `, texts: []string{"ROLLUP"}},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY Cost WITH ROLLUP `, texts: []string{"ORDER BY", "LIMIT"}},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT LIMIT `},
+		{q: `DESC ADGROUP_PERFORMANCE_REPORT `, texts: []string{"AdGroupId", "AdGroupName"}},
+		{q: `SHOW FULL `, texts: []string{"TABLES"}},
+		{q: `CREATE `, texts: []string{"OR REPLACE", "VIEW"}},
+		{q: `CREATE VIEW rv AS SELECT Cost FROM ADGROUP_PERFORMANCE_REPORT WHERE `, texts: []string{"AdGroupId", "AdGroupName"}},
+		{q: `USE 123-456-7890; SHOW `, texts: []string{"FULL", "TABLES"}},
+		{q: `SHOW TABLES; USE 123-456-7890; DESC ADGROUP_PERFORMANCE_REPORT `, texts: []string{"AdGroupId", "AdGroupName"}},
+	}
+	for i, tt := range tests {
+		cursor := tt.cursor
+		if cursor == 0 {
+			cursor = len(tt.q)
+		}
+		list, err := c.Complete(tt.q, cursor)
+		if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.q, err)
+			continue
+		}
+		var texts []string
+		for _, s := range list {
+			texts = append(texts, s.Text)
+			if s.Prefix != tt.prefix {
+				t.Errorf("%d. Expected the prefix %q, received %q", i, tt.prefix, s.Prefix)
+			}
+		}
+		if !reflect.DeepEqual(texts, tt.texts) {
+			t.Errorf("%d. Expected %q with %q, received %q", i, tt.texts, tt.q, texts)
+		}
+	}
+}
+
+func TestComplete(t *testing.T) {
+	list, err := awql.Complete(`SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks `, 58)
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	if len(list) != len(awql.Operators()) || list[0].Kind != awql.OperatorSuggestion {
+		t.Errorf("Expected the operators, received %v", list)
+	}
+	if _, err := awql.Complete(`SELECT`, 7); err == nil {
+		t.Error("Expected an error with a cursor out of the query")
+	}
+}
@@ -0,0 +1,94 @@
+package awqlparse
+
+import (
+	"sort"
+	"strings"
+)
+
+// Dependencies returns for each view created by the statements the tables or views read by its source query.
+// It returns an error if a view depends on itself, directly or through other views.
+func Dependencies(stmts []Stmt) (map[string][]string, error) {
+	deps := make(map[string][]string)
+	for _, stmt := range stmts {
+		v, ok := stmt.(CreateViewStmt)
+		if !ok {
+			continue
+		}
+		name := v.SourceName()
+		if _, ok := deps[name]; !ok {
+			deps[name] = []string{}
+		}
+		Walk(v, func(node interface{}) bool {
+			if s, ok := node.(SelectStmt); ok && !hasString(deps[name], s.SourceName()) {
+				deps[name] = append(deps[name], s.SourceName())
+			}
+			return true
+		})
+	}
+	if _, err := TopoSort(deps); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// TopoSort returns the views of the dependency graph in build order:
+// each view comes after the views it depends on. The tables, without dependency, are ignored.
+// It returns an error describing the first cycle found.
+func TopoSort(deps map[string][]string) ([]string, error) {
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		order []string
+		state = make(map[string]int) // 1: in progress, 2: done
+		path  []string
+		visit func(name string) error
+	)
+	visit = func(name string) error {
+		switch state[name] {
+		case 1:
+			// Extracts the cycle from the current path.
+			for i, p := range path {
+				if p == name {
+					return NewXParserError(ErrMsgViewCycle, strings.Join(append(path[i:], name), " -> "))
+				}
+			}
+		case 2:
+			return nil
+		}
+		state[name] = 1
+		path = append(path, name)
+		for _, dep := range deps[name] {
+			if _, ok := deps[dep]; !ok {
+				// Not a view.
+				continue
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = 2
+		order = append(order, name)
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// hasString returns true if the list contains the string.
+func hasString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
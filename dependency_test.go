@@ -0,0 +1,54 @@
+package awqlparse_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestDependencies(t *testing.T) {
+	q := `CREATE VIEW c AS SELECT Id FROM b;
+	CREATE VIEW a AS SELECT Id FROM CAMPAIGN_PERFORMANCE_REPORT;
+	CREATE VIEW b AS SELECT Id FROM a;
+	SELECT Id FROM c;`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	deps, err := awql.Dependencies(stmts)
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	expected := map[string][]string{
+		"a": {"CAMPAIGN_PERFORMANCE_REPORT"},
+		"b": {"a"},
+		"c": {"b"},
+	}
+	if !reflect.DeepEqual(deps, expected) {
+		t.Errorf("Expected %v, received %v", expected, deps)
+	}
+	order, err := awql.TopoSort(deps)
+	if err != nil {
+		t.Fatalf("Expected no error with %v, received %v", deps, err)
+	}
+	if !reflect.DeepEqual(order, []string{"a", "b", "c"}) {
+		t.Errorf("Expected [a b c] as build order, received %v", order)
+	}
+}
+
+func TestDependencies_Cycle(t *testing.T) {
+	q := `CREATE VIEW A AS SELECT Id FROM B;CREATE VIEW B AS SELECT Id FROM C;CREATE VIEW C AS SELECT Id FROM A;`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	_, err = awql.Dependencies(stmts)
+	if err == nil {
+		t.Fatalf("Expected an error with %q", q)
+	}
+	if !strings.Contains(err.Error(), "A -> B -> C -> A") {
+		t.Errorf("Expected the cycle in the error, received %q", err.Error())
+	}
+}
@@ -0,0 +1,89 @@
+package awqlparse
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Names of the parameters of the report download form.
+const (
+	downloadQuery  = "__rdquery"
+	downloadFormat = "__fmt"
+)
+
+// downloadFormats lists the formats of the reports proposed by Adwords.
+var downloadFormats = []string{
+	"CSVFOREXCEL", "CSV", "TSV", "XML", "GZIPPED_CSV", "GZIPPED_XML",
+}
+
+// DownloadRequest returns the form values to post to the report download endpoint
+// of Adwords, with the query as output by LegacyString and the given format, as CSV.
+// If the statement uses something not supported by Adwords, as the asterisk, an aggregate function,
+// an alias, the GROUP BY, ORDER BY or LIMIT clauses, it returns an UnsupportedError
+// listing all of them instead of downloading another report than the expected one.
+func (s SelectStatement) DownloadRequest(format string) (url.Values, error) {
+	if !isDownloadFormat(format) {
+		return nil, NewXParserError(ErrMsgBadFormat, format)
+	}
+	if !s.valid() {
+		return nil, NewParserError(ErrMsgMissingSrc)
+	}
+	if clauses := s.unsupportedClauses(); len(clauses) > 0 {
+		return nil, &UnsupportedError{Clauses: clauses}
+	}
+
+	v := url.Values{}
+	v.Set(downloadQuery, s.LegacyString())
+	v.Set(downloadFormat, strings.ToUpper(format))
+
+	return v, nil
+}
+
+// unsupportedClauses lists the parts of the statement not supported by Adwords.
+func (s SelectStatement) unsupportedClauses() []string {
+	var clauses []string
+	if s.hasWildcard() {
+		clauses = append(clauses, "*")
+	}
+	if len(s.ExcludedList()) > 0 {
+		clauses = append(clauses, "EXCEPT")
+	}
+	if s.Distinct() {
+		clauses = append(clauses, "DISTINCT")
+	}
+	for _, c := range s.Columns() {
+		method, distinct, ok := c.Aggregate()
+		if ok {
+			clauses = append(clauses, method+"("+c.Name()+")")
+		}
+		if distinct {
+			clauses = append(clauses, "DISTINCT "+c.Name())
+		}
+		if alias, ok := c.Alias(); ok {
+			clauses = append(clauses, "AS "+alias)
+		}
+	}
+	if len(s.GroupList()) > 0 {
+		clauses = append(clauses, "GROUP BY")
+	}
+	if s.WithRollup() {
+		clauses = append(clauses, "WITH ROLLUP")
+	}
+	if len(s.OrderList()) > 0 {
+		clauses = append(clauses, "ORDER BY")
+	}
+	if _, ok := s.PageSize(); ok {
+		clauses = append(clauses, "LIMIT")
+	}
+	return clauses
+}
+
+// isDownloadFormat returns true if the format of report is known by Adwords.
+func isDownloadFormat(format string) bool {
+	for _, f := range downloadFormats {
+		if strings.EqualFold(f, format) {
+			return true
+		}
+	}
+	return false
+}
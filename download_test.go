@@ -0,0 +1,49 @@
+package awqlparse_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestSelectStmt_DownloadRequest(t *testing.T) {
+	q := `SELECT CampaignId, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0 DURING YESTERDAY\G`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	stmt := stmts[0].(awql.SelectStmt)
+
+	v, err := stmt.DownloadRequest("csv")
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	if s := v.Get("__rdquery"); s != "SELECT CampaignId, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0 DURING YESTERDAY" {
+		t.Errorf("Unexpected query with %q, received %q", q, s)
+	}
+	if s := v.Get("__fmt"); s != "CSV" {
+		t.Errorf("Expected CSV as format, received %q", s)
+	}
+	if _, err := stmt.DownloadRequest("PDF"); err == nil {
+		t.Error("Expected an error with an unknown format")
+	}
+}
+
+func TestSelectStmt_DownloadRequestUnsupported(t *testing.T) {
+	q := `SELECT CampaignId, SUM(Clicks) AS c FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1 ORDER BY 2 LIMIT 5`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	_, err = stmts[0].(awql.SelectStmt).DownloadRequest("CSV")
+	uerr, ok := err.(*awql.UnsupportedError)
+	if !ok {
+		t.Fatalf("Expected an UnsupportedError with %q, received %v", q, err)
+	}
+	expected := []string{"SUM(Clicks)", "AS c", "GROUP BY", "ORDER BY", "LIMIT"}
+	if !reflect.DeepEqual(uerr.Clauses, expected) {
+		t.Errorf("Expected %v with %q, received %v", expected, q, uerr.Clauses)
+	}
+}
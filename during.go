@@ -0,0 +1,128 @@
+package awqlparse
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Layout of the dates of the during clause.
+const dateLayout = "20060102"
+
+// dateRanges lists the date range literals accepted by the during clause.
+var dateRanges = struct {
+	sync.RWMutex
+	m map[string]bool
+}{
+	m: map[string]bool{
+		"TODAY": true, "YESTERDAY": true,
+		"THIS_WEEK_SUN_TODAY": true, "THIS_WEEK_MON_TODAY": true,
+		"LAST_WEEK": true, "LAST_7_DAYS": true, "LAST_14_DAYS": true,
+		"LAST_30_DAYS": true, "LAST_90_DAYS": true, "LAST_BUSINESS_WEEK": true,
+		"LAST_WEEK_SUN_SAT": true, "THIS_MONTH": true, "LAST_MONTH": true,
+		"ALL_TIME": true,
+	},
+}
+
+// DateRangeLiterals returns the date range literals accepted by the during clause, sorted by name.
+func DateRangeLiterals() []string {
+	dateRanges.RLock()
+	defer dateRanges.RUnlock()
+	list := make([]string, 0, len(dateRanges.m))
+	for s := range dateRanges.m {
+		list = append(list, s)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// RegisterDateRangeLiteral adds a date range literal to the ones accepted by the during clause,
+// to follow the new versions of the Adwords API. As the other keywords, it is case-insensitive.
+// It is safe for concurrent use. DuringRange can not resolve it and so returns an error for it.
+func RegisterDateRangeLiteral(literal string) {
+	if literal == "" {
+		return
+	}
+	dateRanges.Lock()
+	dateRanges.m[strings.ToUpper(literal)] = true
+	dateRanges.Unlock()
+}
+
+// DuringRange returns the first and the last days of the date range of the during clause,
+// at midnight in the location of now. The date range literals are resolved relatively to now,
+// as Adwords does: LAST_7_DAYS ends yesterday, LAST_WEEK goes from Monday to Sunday, etc.
+// With ALL_TIME, the first day is the zero time.
+// It returns a DuringError if the clause is missing or malformed.
+func (s SelectStatement) DuringRange(now time.Time) (start, end time.Time, err error) {
+	d := s.DuringList()
+	switch len(d) {
+	case 0:
+		err = &DuringError{Reason: ErrMsgDuringMissing}
+	case 1:
+		var ok bool
+		if start, end, ok = dateRange(strings.ToUpper(d[0]), now); !ok {
+			err = &DuringError{During: d, Reason: ErrMsgDuringLitSize}
+		}
+	case 2:
+		loc := now.Location()
+		if start, err = time.ParseInLocation(dateLayout, d[0], loc); err != nil {
+			return start, end, &DuringError{During: d, Reason: ErrMsgDuringDateSize}
+		}
+		if end, err = time.ParseInLocation(dateLayout, d[1], loc); err != nil {
+			return start, end, &DuringError{During: d, Reason: ErrMsgDuringDateSize}
+		}
+		if start.After(end) {
+			err = &DuringError{During: d, Reason: ErrMsgDuringOrder}
+		}
+	default:
+		err = &DuringError{During: d, Reason: ErrMsgDuringSize}
+	}
+	return
+}
+
+// dateRange returns the first and last days of the date range literal.
+// The last parameter is false if the literal is unknown.
+func dateRange(literal string, now time.Time) (start, end time.Time, ok bool) {
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	days := func(n int) time.Time {
+		return today.AddDate(0, 0, n)
+	}
+	// Days since the last Sunday and the last Monday.
+	sun := int(today.Weekday())
+	mon := (sun + 6) % 7
+
+	switch literal {
+	case "TODAY":
+		return today, today, true
+	case "YESTERDAY":
+		return days(-1), days(-1), true
+	case "LAST_7_DAYS":
+		return days(-7), days(-1), true
+	case "LAST_14_DAYS":
+		return days(-14), days(-1), true
+	case "LAST_30_DAYS":
+		return days(-30), days(-1), true
+	case "LAST_90_DAYS":
+		return days(-90), days(-1), true
+	case "THIS_WEEK_SUN_TODAY":
+		return days(-sun), today, true
+	case "THIS_WEEK_MON_TODAY":
+		return days(-mon), today, true
+	case "LAST_WEEK":
+		return days(-mon - 7), days(-mon - 1), true
+	case "LAST_WEEK_SUN_SAT":
+		return days(-sun - 7), days(-sun - 1), true
+	case "LAST_BUSINESS_WEEK":
+		return days(-mon - 7), days(-mon - 3), true
+	case "THIS_MONTH":
+		return time.Date(y, m, 1, 0, 0, 0, 0, now.Location()), today, true
+	case "LAST_MONTH":
+		first := time.Date(y, m, 1, 0, 0, 0, 0, now.Location())
+		return first.AddDate(0, -1, 0), first.AddDate(0, 0, -1), true
+	case "ALL_TIME":
+		return time.Time{}, today, true
+	}
+	return
+}
@@ -0,0 +1,111 @@
+package awqlparse_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestSelectStmt_DuringRange(t *testing.T) {
+	var (
+		sun = time.Date(2016, 12, 25, 15, 4, 5, 0, time.UTC)
+		mon = time.Date(2016, 12, 26, 0, 0, 0, 0, time.UTC)
+		sat = time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC)
+	)
+	var tests = []struct {
+		during     string
+		now        time.Time
+		start, end string
+		err        bool
+	}{
+		{during: "TODAY", now: sun, start: "20161225", end: "20161225"},
+		{during: "YESTERDAY", now: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), start: "20161231", end: "20161231"},
+		{during: "LAST_7_DAYS", now: mon, start: "20161219", end: "20161225"},
+		{during: "LAST_14_DAYS", now: mon, start: "20161212", end: "20161225"},
+		{during: "LAST_30_DAYS", now: mon, start: "20161126", end: "20161225"},
+		{during: "LAST_90_DAYS", now: mon, start: "20160927", end: "20161225"},
+		{during: "THIS_MONTH", now: mon, start: "20161201", end: "20161226"},
+		{during: "LAST_MONTH", now: mon, start: "20161101", end: "20161130"},
+		{during: "LAST_MONTH", now: time.Date(2017, 3, 31, 0, 0, 0, 0, time.UTC), start: "20170201", end: "20170228"},
+		{during: "ALL_TIME", now: mon, start: "00010101", end: "20161226"},
+		// Week boundaries.
+		{during: "THIS_WEEK_SUN_TODAY", now: sun, start: "20161225", end: "20161225"},
+		{during: "THIS_WEEK_SUN_TODAY", now: mon, start: "20161225", end: "20161226"},
+		{during: "THIS_WEEK_SUN_TODAY", now: sat, start: "20161225", end: "20161231"},
+		{during: "THIS_WEEK_MON_TODAY", now: sun, start: "20161219", end: "20161225"},
+		{during: "THIS_WEEK_MON_TODAY", now: mon, start: "20161226", end: "20161226"},
+		{during: "THIS_WEEK_MON_TODAY", now: sat, start: "20161226", end: "20161231"},
+		{during: "LAST_WEEK", now: sun, start: "20161212", end: "20161218"},
+		{during: "LAST_WEEK", now: mon, start: "20161219", end: "20161225"},
+		{during: "LAST_WEEK_SUN_SAT", now: sun, start: "20161218", end: "20161224"},
+		{during: "LAST_WEEK_SUN_SAT", now: sat, start: "20161218", end: "20161224"},
+		{during: "LAST_BUSINESS_WEEK", now: sun, start: "20161212", end: "20161216"},
+		{during: "LAST_BUSINESS_WEEK", now: mon, start: "20161219", end: "20161223"},
+		{during: "LAST_BUSINESS_WEEK", now: sat, start: "20161219", end: "20161223"},
+		// Explicit dates.
+		{during: "20161224,20161225", now: sun, start: "20161224", end: "20161225"},
+		{now: sun, err: true},
+	}
+
+	for i, tt := range tests {
+		q := "SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT"
+		if tt.during != "" {
+			q += " DURING " + tt.during
+		}
+		stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, q, err)
+		}
+		start, end, err := stmts[0].(awql.SelectStmt).DuringRange(tt.now)
+		if tt.err {
+			if _, ok := err.(*awql.DuringError); !ok {
+				t.Errorf("%d. Expected a DuringError with %q, received %v", i, q, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, q, err)
+		}
+		if s, e := start.Format("20060102"), end.Format("20060102"); s != tt.start || e != tt.end {
+			t.Errorf("%d. Expected %v-%v with %q, received %v-%v", i, tt.start, tt.end, q, s, e)
+		}
+	}
+
+	// Unordered dates, rejected by the parser.
+	stmt := awql.SelectStatement{During: []string{"20161225", "20161224"}}
+	if _, _, err := stmt.DuringRange(sun); err == nil {
+		t.Error("Expected an error with a start date after the end date")
+	}
+}
+
+func TestRegisterDateRangeLiteral(t *testing.T) {
+	q := "SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING LAST_MONTH"
+	stmt, err := awql.NewParser(strings.NewReader(q)).ParseSelect()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	if d := stmt.DuringList(); len(d) != 1 || d[0] != "LAST_MONTH" {
+		t.Errorf("Expected LAST_MONTH as during with %q, received %v", q, d)
+	}
+
+	q = "SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING LAST_6_MONTHS"
+	if _, err := awql.NewParser(strings.NewReader(q)).ParseSelect(); err == nil {
+		t.Fatalf("Expected an error with an unknown literal in %q", q)
+	}
+	awql.RegisterDateRangeLiteral("LAST_6_MONTHS")
+	// The literal is only known by this test.
+	defer awql.UnregisterDateRangeLiteral("LAST_6_MONTHS")
+	if _, err := awql.NewParser(strings.NewReader(q)).ParseSelect(); err != nil {
+		t.Errorf("Expected no error with a registered literal in %q, received %v", q, err)
+	}
+
+	var found bool
+	for _, s := range awql.DateRangeLiterals() {
+		found = found || s == "LAST_6_MONTHS"
+	}
+	if !found {
+		t.Errorf("Expected LAST_6_MONTHS in the list of literals, received %v", awql.DateRangeLiterals())
+	}
+}
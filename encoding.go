@@ -0,0 +1,106 @@
+package awqlparse
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// encodingVersion is the version of the binary layout of the statements.
+// It must be incremented each time the statement structs change.
+const encodingVersion byte = 11
+
+// Registers the concrete types behind the interfaces of the statements,
+// in order to encode a list of Stmt with the gob package.
+func init() {
+	gob.Register(&Column{})
+	gob.Register(&DynamicColumn{})
+	gob.Register(&ColumnPosition{})
+	gob.Register(&Where{})
+	gob.Register(&Order{})
+	gob.Register(&CreateViewStatement{})
+	gob.Register(&DescribeStatement{})
+	gob.Register(&SelectStatement{})
+	gob.Register(&ShowStatement{})
+	gob.Register(&UseStatement{})
+}
+
+// Defined types without the binary methods, used to avoid recursive calls.
+type (
+	createViewStatement CreateViewStatement
+	describeStatement   DescribeStatement
+	selectStatement     SelectStatement
+	showStatement       ShowStatement
+	useStatement        UseStatement
+)
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s CreateViewStatement) MarshalBinary() ([]byte, error) {
+	return marshalBinary(createViewStatement(s))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *CreateViewStatement) UnmarshalBinary(data []byte) error {
+	return unmarshalBinary(data, (*createViewStatement)(s))
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s DescribeStatement) MarshalBinary() ([]byte, error) {
+	return marshalBinary(describeStatement(s))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *DescribeStatement) UnmarshalBinary(data []byte) error {
+	return unmarshalBinary(data, (*describeStatement)(s))
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s SelectStatement) MarshalBinary() ([]byte, error) {
+	return marshalBinary(selectStatement(s))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *SelectStatement) UnmarshalBinary(data []byte) error {
+	return unmarshalBinary(data, (*selectStatement)(s))
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s ShowStatement) MarshalBinary() ([]byte, error) {
+	return marshalBinary(showStatement(s))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *ShowStatement) UnmarshalBinary(data []byte) error {
+	return unmarshalBinary(data, (*showStatement)(s))
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (s UseStatement) MarshalBinary() ([]byte, error) {
+	return marshalBinary(useStatement(s))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (s *UseStatement) UnmarshalBinary(data []byte) error {
+	return unmarshalBinary(data, (*useStatement)(s))
+}
+
+// marshalBinary encodes the value with gob, prefixed by the version of the layout.
+// The columns shared between the clauses of a statement are encoded as copies.
+func marshalBinary(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(encodingVersion)
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalBinary decodes data in the value v if it has been written with the current layout.
+func unmarshalBinary(data []byte, v interface{}) error {
+	if len(data) == 0 {
+		return NewParserError(ErrMsgBadEncoding)
+	}
+	if data[0] != encodingVersion {
+		return NewXParserError(ErrMsgBadEncoding, data[0])
+	}
+	return gob.NewDecoder(bytes.NewReader(data[1:])).Decode(v)
+}
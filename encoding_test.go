@@ -0,0 +1,72 @@
+package awqlparse_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestStmt_Gob(t *testing.T) {
+	q := `SELECT CampaignId, SUM(Clicks) AS c FROM CAMPAIGN_PERFORMANCE_REPORT
+	WHERE CampaignStatus IN ["ENABLED", "PAUSED"] AND Impressions > 10
+	DURING 20161224,20161225 GROUP BY 1 ORDER BY 2 DESC LIMIT 5, 10\G
+	CREATE OR REPLACE VIEW rv (id, clicks) AS SELECT CampaignId, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT ORDER BY 2;
+	DESC FULL CAMPAIGN_PERFORMANCE_REPORT CampaignId;
+	SHOW TABLES LIKE "CAMPAIGN%";
+	USE 123-456-7890;`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(stmts); err != nil {
+		t.Fatalf("Expected no error while encoding, received %v", err)
+	}
+	var res []awql.Stmt
+	if err := gob.NewDecoder(&buf).Decode(&res); err != nil {
+		t.Fatalf("Expected no error while decoding, received %v", err)
+	}
+	if len(res) != len(stmts) {
+		t.Fatalf("Expected %d statements, received %d", len(stmts), len(res))
+	}
+	for i, stmt := range stmts {
+		if !reflect.DeepEqual(stmt, res[i]) {
+			t.Errorf("%d. Expected %#v, received %#v", i, stmt, res[i])
+		}
+		if stmt.String() != res[i].String() {
+			t.Errorf("%d. Expected %q, received %q", i, stmt.String(), res[i].String())
+		}
+	}
+}
+
+func TestSelectStatement_UnmarshalBinary(t *testing.T) {
+	stmt := awql.SelectStatement{}
+	stmt.Fields = []awql.DynamicField{awql.NewDynamicColumn(awql.NewColumn("CampaignId", ""), "", false)}
+	stmt.TableName = "CAMPAIGN_PERFORMANCE_REPORT"
+
+	data, err := stmt.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected no error while encoding, received %v", err)
+	}
+	var res awql.SelectStatement
+	if err := res.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Expected no error while decoding, received %v", err)
+	}
+	if !reflect.DeepEqual(stmt, res) {
+		t.Errorf("Expected %#v, received %#v", stmt, res)
+	}
+
+	// Data written by another layout.
+	data[0]++
+	if err := res.UnmarshalBinary(data); err == nil {
+		t.Error("Expected an error with an unknown encoding version")
+	}
+	if err := res.UnmarshalBinary(nil); err == nil {
+		t.Error("Expected an error without data")
+	}
+}
@@ -0,0 +1,180 @@
+package awqlparse
+
+import "strings"
+
+// Equal returns true if the other statement is a create view statement with the same meaning.
+// The keywords and methods are compared without case, the output format is ignored.
+func (s CreateViewStatement) Equal(other Stmt) bool {
+	o, ok := other.(CreateViewStmt)
+	if !ok || s.ReplaceMode() != o.ReplaceMode() || !dataEqual(s, o) {
+		return false
+	}
+	v1, v2 := s.SourceQuery(), o.SourceQuery()
+	if isNilSelect(v1) || isNilSelect(v2) {
+		return isNilSelect(v1) && isNilSelect(v2)
+	}
+	return selectEqual(v1, v2)
+}
+
+// Equal returns true if the other statement is a describe statement with the same meaning.
+func (s DescribeStatement) Equal(other Stmt) bool {
+	o, ok := other.(DescribeStmt)
+	return ok && s.FullMode() == o.FullMode() && dataEqual(s, o)
+}
+
+// Equal returns true if the other statement is a select statement with the same meaning.
+// The keywords and methods are compared without case, aliases and values exactly.
+// A nil list equals an empty one and the output format is ignored.
+func (s SelectStatement) Equal(other Stmt) bool {
+	o, ok := other.(SelectStmt)
+	return ok && selectEqual(s, o)
+}
+
+// Equal returns true if the other statement is a show statement with the same meaning.
+func (s ShowStatement) Equal(other Stmt) bool {
+	o, ok := other.(ShowStmt)
+	if !ok || s.FullMode() != o.FullMode() {
+		return false
+	}
+	p1, u1 := s.LikePattern()
+	p2, u2 := o.LikePattern()
+	if u1 != u2 || p1.String() != p2.String() {
+		return false
+	}
+	w1, u1 := s.WithFieldName()
+	w2, u2 := o.WithFieldName()
+	return u1 == u2 && w1 == w2
+}
+
+// Equal returns true if the other statement is a use statement on the same account.
+func (s UseStatement) Equal(other Stmt) bool {
+	o, ok := other.(UseStmt)
+	return ok && s.AccountID() == o.AccountID()
+}
+
+// StmtsEqual returns true if both lists have the same statements, in the same order.
+func StmtsEqual(a, b []Stmt) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, s := range a {
+		e, ok := s.(interface {
+			Equal(Stmt) bool
+		})
+		if !ok || !e.Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNilSelect returns true if the statement is nil or a nil pointer.
+func isNilSelect(s SelectStmt) bool {
+	if s == nil {
+		return true
+	}
+	p, ok := s.(*SelectStatement)
+	return ok && p == nil
+}
+
+// dataEqual returns true if both statements use the same source and fields.
+func dataEqual(a, b DataStmt) bool {
+	if a.SourceName() != b.SourceName() {
+		return false
+	}
+	f1, f2 := a.Columns(), b.Columns()
+	if len(f1) != len(f2) {
+		return false
+	}
+	for i, f := range f1 {
+		m1, d1, _ := f.Aggregate()
+		m2, d2, _ := f2[i].Aggregate()
+		a1, _ := f.Alias()
+		a2, _ := f2[i].Alias()
+		if f.Name() != f2[i].Name() || a1 != a2 || d1 != d2 || !strings.EqualFold(m1, m2) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectEqual returns true if both select statements have the same meaning.
+func selectEqual(a, b SelectStmt) bool {
+	if !dataEqual(a, b) || a.Distinct() != b.Distinct() {
+		return false
+	}
+
+	// Excluded columns.
+	e1, e2 := a.ExcludedList(), b.ExcludedList()
+	if len(e1) != len(e2) {
+		return false
+	}
+	for i, e := range e1 {
+		if e.Name() != e2[i].Name() {
+			return false
+		}
+	}
+
+	// Conditions.
+	c1, c2 := a.ConditionList(), b.ConditionList()
+	if len(c1) != len(c2) {
+		return false
+	}
+	for i, c := range c1 {
+		v1, l1 := c.Value()
+		v2, l2 := c2[i].Value()
+		if c.Name() != c2[i].Name() || !strings.EqualFold(c.Operator(), c2[i].Operator()) ||
+			l1 != l2 || !stringsEqual(v1, v2, false) {
+			return false
+		}
+	}
+
+	// Date range, a date range literal is a keyword.
+	if !stringsEqual(a.DuringList(), b.DuringList(), true) {
+		return false
+	}
+
+	// Groups and orders.
+	g1, g2 := a.GroupList(), b.GroupList()
+	if len(g1) != len(g2) {
+		return false
+	}
+	for i, g := range g1 {
+		if g.Position() != g2[i].Position() {
+			return false
+		}
+	}
+	if a.WithRollup() != b.WithRollup() {
+		return false
+	}
+	o1, o2 := a.OrderList(), b.OrderList()
+	if len(o1) != len(o2) {
+		return false
+	}
+	for i, o := range o1 {
+		if o.Position() != o2[i].Position() || o.SortDescending() != o2[i].SortDescending() {
+			return false
+		}
+	}
+
+	// Limit, the start index is ignored without row count.
+	r1, ok1 := a.PageSize()
+	r2, ok2 := b.PageSize()
+	if ok1 != ok2 {
+		return false
+	}
+	return !ok1 || (r1 == r2 && a.StartIndex() == b.StartIndex())
+}
+
+// stringsEqual returns true if both lists have the same values, optionally without case.
+func stringsEqual(a, b []string, fold bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, s := range a {
+		if (fold && !strings.EqualFold(s, b[i])) || (!fold && s != b[i]) {
+			return false
+		}
+	}
+	return true
+}
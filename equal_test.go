@@ -0,0 +1,101 @@
+package awqlparse_test
+
+import (
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestStmtsEqual(t *testing.T) {
+	var tests = []struct {
+		q1, q2 string
+		ok     bool
+	}{
+		{
+			q1: `SELECT CampaignId, SUM(Clicks) AS c FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus in ["ENABLED"] DURING LAST_7_DAYS GROUP BY 1 ORDER BY 2 desc`,
+			q2: `select CampaignId, sum(Clicks) as c from CAMPAIGN_PERFORMANCE_REPORT where CampaignStatus IN ['ENABLED'] during LAST_7_DAYS group by CampaignId order by c DESC\G`,
+			ok: true,
+		},
+		{
+			q1: `SELECT CampaignId, Clicks AS c FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			q2: `SELECT CampaignId, Clicks AS C FROM CAMPAIGN_PERFORMANCE_REPORT`,
+		},
+		{
+			q1: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus = "ENABLED"`,
+			q2: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus = "enabled"`,
+		},
+		{
+			q1: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT LIMIT 5`,
+			q2: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT LIMIT 0, 5`,
+			ok: true,
+		},
+		{
+			q1: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT LIMIT 5`,
+			q2: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT LIMIT 1, 5`,
+		},
+		{
+			q1: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT ORDER BY 1`,
+			q2: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT ORDER BY 1 DESC`,
+		},
+		{
+			q1: `CREATE VIEW rv AS SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			q2: `create view rv as select CampaignId from CAMPAIGN_PERFORMANCE_REPORT`,
+			ok: true,
+		},
+		{
+			q1: `CREATE VIEW rv AS SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			q2: `CREATE OR REPLACE VIEW rv AS SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT`,
+		},
+		{
+			q1: `DESC FULL CAMPAIGN_PERFORMANCE_REPORT CampaignId;SHOW TABLES LIKE "C%";USE 123`,
+			q2: `describe full CAMPAIGN_PERFORMANCE_REPORT CampaignId;show tables like 'C%';use 123`,
+			ok: true,
+		},
+		{
+			q1: `DESC CAMPAIGN_PERFORMANCE_REPORT`,
+			q2: `DESC FULL CAMPAIGN_PERFORMANCE_REPORT`,
+		},
+		{
+			q1: `SHOW TABLES WITH CampaignId`,
+			q2: `SHOW TABLES LIKE "CampaignId"`,
+		},
+		{
+			q1: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			q2: `DESC CAMPAIGN_PERFORMANCE_REPORT CampaignId`,
+		},
+		{
+			q1: `USE 123;USE 456`,
+			q2: `USE 123`,
+		},
+	}
+
+	for i, tt := range tests {
+		s1, err := awql.NewParser(strings.NewReader(tt.q1)).Parse()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.q1, err)
+		}
+		s2, err := awql.NewParser(strings.NewReader(tt.q2)).Parse()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.q2, err)
+		}
+		if ok := awql.StmtsEqual(s1, s2); ok != tt.ok {
+			t.Errorf("%d. Expected %v comparing %q with %q, received %v", i, tt.ok, tt.q1, tt.q2, ok)
+		}
+	}
+}
+
+func TestSelectStatement_Equal(t *testing.T) {
+	s1 := awql.SelectStatement{}
+	s1.Fields = []awql.DynamicField{awql.NewDynamicColumn(awql.NewColumn("CampaignId", ""), "", false)}
+	s1.TableName = "CAMPAIGN_PERFORMANCE_REPORT"
+	s2 := s1
+	s2.Where = []awql.Condition{}
+	s2.During = []string{}
+	if !s1.Equal(s2) || !s1.Equal(&s2) {
+		t.Error("Expected nil and empty lists to be equal")
+	}
+	if s1.Equal(nil) {
+		t.Error("Expected a statement different from nil")
+	}
+}
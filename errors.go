@@ -7,8 +7,13 @@ import (
 
 // ParserError represents an error of parse.
 type ParserError struct {
-	s string
-	a interface{}
+	s          string
+	a          interface{}
+	h          string
+	r          string
+	pos        Pos
+	stmt       int
+	incomplete bool
 }
 
 // NewParserError returns an error with the parsing.
@@ -21,12 +26,61 @@ func NewXParserError(text string, arg interface{}) error {
 	return &ParserError{s: formatError(text), a: arg}
 }
 
+// newHintParserError returns an error with the parsing with a suggestion to fix it.
+func newHintParserError(text string, arg interface{}, hint string) error {
+	return &ParserError{s: formatError(text), a: arg, h: hint}
+}
+
+// wrapParserError returns an error of the given kind caused by another one.
+// A parse error keeps its argument and gives its kind as reason, instead of nesting its message.
+func wrapParserError(text string, err error) error {
+	e, ok := err.(*ParserError)
+	if !ok {
+		return NewXParserError(text, err.Error())
+	}
+	return &ParserError{s: formatError(text), a: e.a, r: e.s}
+}
+
 // Error returns the message of the parse error.
+// The position and the index of the statement, if known, follow the message, then its reason.
+// The suggestion, if any, is added at the end of the message.
 func (e *ParserError) Error() string {
+	var msg string
 	if e.a != nil {
-		return fmt.Sprintf("ParserError.%v (%v)", e.s, e.a)
+		msg = fmt.Sprintf("ParserError.%v (%v)", e.s, e.a)
+	} else {
+		msg = fmt.Sprintf("ParserError.%v", e.s)
+	}
+	if e.pos.Line > 0 {
+		msg += fmt.Sprintf(" at line %d", e.pos.Line)
+	}
+	if e.stmt > 0 {
+		msg += fmt.Sprintf(" of statement %d", e.stmt)
 	}
-	return fmt.Sprintf("ParserError.%v", e.s)
+	if e.r != "" {
+		msg += ": " + e.r
+	}
+	if e.h != "" {
+		msg += fmt.Sprintf(", did you mean %v?", e.h)
+	}
+	return msg
+}
+
+// Position returns the position of the error in the input, if known.
+func (e *ParserError) Position() (Pos, bool) {
+	return e.pos, e.pos.Line > 0
+}
+
+// Hint returns the suggestion to fix the error or an empty string.
+func (e *ParserError) Hint() string {
+	return e.h
+}
+
+// IsIncomplete returns true if the error is raised by the end of the input
+// where more tokens are expected: the statement is not finished yet.
+func IsIncomplete(err error) bool {
+	e, ok := err.(*ParserError)
+	return ok && e.incomplete
 }
 
 // formatError returns a string in upper case with underscore instead of space.
@@ -34,3 +88,38 @@ func (e *ParserError) Error() string {
 func formatError(s string) string {
 	return strings.Replace(strings.ToUpper(strings.TrimSpace(s)), " ", "_", -1)
 }
+
+// UnsupportedError represents the clauses of a statement not supported by Adwords.
+type UnsupportedError struct {
+	Clauses []string
+}
+
+// Error returns the message of the error with the list of unsupported clauses.
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("ParserError.%v (%v)", formatError(ErrMsgUnsupported), strings.Join(e.Clauses, ", "))
+}
+
+// DuringError represents an error with the date range of the during clause.
+type DuringError struct {
+	During []string
+	Reason string
+}
+
+// Error returns the message of the error with the reason and the date range.
+func (e *DuringError) Error() string {
+	if len(e.During) == 0 {
+		return fmt.Sprintf("ParserError.%v (%v)", formatError(ErrMsgBadDuring), e.Reason)
+	}
+	return fmt.Sprintf("ParserError.%v (%v: %v)", formatError(ErrMsgBadDuring), e.Reason, strings.Join(e.During, ","))
+}
+
+// LimitError represents an error raised when the input exceeds one of the limits of the parser.
+type LimitError struct {
+	Limit string
+	Max   int64
+}
+
+// Error returns the message of the error with the name and the value of the limit.
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("ParserError.%v (%v: %d)", formatError(ErrMsgLimitExceeded), e.Limit, e.Max)
+}
@@ -0,0 +1,68 @@
+package awqlparse_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestIsIncomplete(t *testing.T) {
+	var tests = []struct {
+		q          string
+		incomplete bool
+	}{
+		{q: `SELECT`, incomplete: true},
+		{q: `SELECT Cost`, incomplete: true},
+		{q: `SELECT Cost,`, incomplete: true},
+		{q: `SELECT Cost FROM`, incomplete: true},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE`, incomplete: true},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks >`, incomplete: true},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName = "rv`, incomplete: true},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId IN [1, 2`, incomplete: true},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT ORDER BY`, incomplete: true},
+		{q: `CREATE VIEW rv AS`, incomplete: true},
+		{q: `DESC`, incomplete: true},
+		{q: `SHOW TABLES LIKE`, incomplete: true},
+		{q: `USE`, incomplete: true},
+		// Errors for other reasons.
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0 ORDER BY 3`},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT DURING 20991231,20160101`},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE !`},
+		{q: `CREATE VIEW rv (a, b) AS SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT`},
+		{q: `SLECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT`},
+		{q: ``},
+	}
+	for i, tt := range tests {
+		_, err := awql.NewParser(strings.NewReader(tt.q)).Parse()
+		if err == nil {
+			t.Errorf("%d. Expected an error with %q", i, tt.q)
+		} else if awql.IsIncomplete(err) != tt.incomplete {
+			t.Errorf("%d. Expected incomplete to be %v with %q, received %v (%v)", i, tt.incomplete, tt.q, !tt.incomplete, err)
+		}
+	}
+	if awql.IsIncomplete(errors.New("rv")) || awql.IsIncomplete(nil) {
+		t.Error("Expected only parser errors to be incomplete")
+	}
+}
+
+func TestParserError_Unterminated(t *testing.T) {
+	q := `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName = 'oops`
+	_, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if msg := "ParserError.UNTERMINATED_STRING ('oops)"; err == nil || err.Error() != msg {
+		t.Errorf("Expected the error %q, received %v", msg, err)
+	}
+}
+
+func TestParserError_Wrapped(t *testing.T) {
+	const msg = "ParserError.INVALID_GROUP_BY (Clicks): UNKNOWN_COLUMN"
+	_, err := awql.NewParser(strings.NewReader(`SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY Clicks`)).Parse()
+	if err == nil || err.Error() != msg {
+		t.Errorf("Expected the error %q with the parser, received %v", msg, err)
+	}
+	_, err = awql.NewSelect("CAMPAIGN_PERFORMANCE_REPORT").Columns("Cost").GroupBy("Clicks").Build()
+	if err == nil || err.Error() != msg {
+		t.Errorf("Expected the error %q with the builder, received %v", msg, err)
+	}
+}
@@ -0,0 +1,10 @@
+package awqlparse
+
+import "strings"
+
+// UnregisterDateRangeLiteral removes a date range literal registered by a test.
+func UnregisterDateRangeLiteral(literal string) {
+	dateRanges.Lock()
+	delete(dateRanges.m, strings.ToUpper(literal))
+	dateRanges.Unlock()
+}
@@ -0,0 +1,34 @@
+package awqlparse
+
+// Placeholder of the literal values in a fingerprint.
+const placeholder = "?"
+
+// Normalize outputs the select statement in its canonical form:
+// keywords in upper case, standard spacing and quoting, without the G modifier.
+// Two equivalent queries, only differing by their formatting, have the same normalized form.
+func (s SelectStatement) Normalize() string {
+	return s.String()
+}
+
+// Fingerprint outputs the normalized select statement with the values of
+// the conditions and the dates of the during clause replaced by a question mark.
+// Thus, queries only differing by their literal values share the same fingerprint.
+// The named date ranges, as LAST_7_DAYS, are kept.
+func (s SelectStatement) Fingerprint() string {
+	if len(s.Where) > 0 {
+		where := make([]Condition, len(s.Where))
+		for i, c := range s.Where {
+			where[i] = &Where{
+				Column:         NewColumn(c.Name(), c.Alias()),
+				Sign:           c.Operator(),
+				ColumnValue:    []string{placeholder},
+				IsValueLiteral: true,
+			}
+		}
+		s.Where = where
+	}
+	if len(s.During) == 2 {
+		s.During = []string{placeholder, placeholder}
+	}
+	return s.Normalize()
+}
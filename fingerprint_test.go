@@ -0,0 +1,48 @@
+package awqlparse_test
+
+import (
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestSelectStmt_Normalize(t *testing.T) {
+	var tests = []struct {
+		q, nq, fq string
+	}{
+		{
+			q:  `select Cost from CAMPAIGN_PERFORMANCE_REPORT where CampaignId=1`,
+			nq: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = 1`,
+			fq: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = ?`,
+		},
+		{
+			q:  "SELECT   Cost\nFROM CAMPAIGN_PERFORMANCE_REPORT\tWHERE CampaignId = 2\\G",
+			nq: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = 2`,
+			fq: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = ?`,
+		},
+		{
+			q:  `SELECT sum(Cost) as c FROM R WHERE Name contains "rv" AND Status in ["ENABLED","PAUSED"] DURING LAST_7_DAYS ORDER BY c desc`,
+			nq: `SELECT SUM(Cost) AS c FROM R WHERE Name CONTAINS 'rv' AND Status IN [ 'ENABLED' , 'PAUSED' ] DURING LAST_7_DAYS ORDER BY 1 DESC`,
+			fq: `SELECT SUM(Cost) AS c FROM R WHERE Name CONTAINS ? AND Status IN ? DURING LAST_7_DAYS ORDER BY 1 DESC`,
+		},
+		{
+			q:  `SELECT Cost FROM R DURING 20161224,20161225 LIMIT 10;`,
+			nq: `SELECT Cost FROM R DURING 20161224,20161225 LIMIT 10`,
+			fq: `SELECT Cost FROM R DURING ?,? LIMIT 10`,
+		},
+	}
+
+	for i, qt := range tests {
+		stmt, err := awql.NewParser(strings.NewReader(qt.q)).ParseSelect()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with '%v', received %v", i, qt.q, err)
+		}
+		if q := stmt.Normalize(); q != qt.nq {
+			t.Errorf("%d. Expected the normalized query '%v' with '%s', received '%v'", i, qt.nq, qt.q, q)
+		}
+		if q := stmt.Fingerprint(); q != qt.fq {
+			t.Errorf("%d. Expected the fingerprint '%v' with '%s', received '%v'", i, qt.fq, qt.q, q)
+		}
+	}
+}
@@ -1,233 +1,387 @@
 package awqlparse
 
-import "strconv"
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
 
 // String outputs a create view statement.
-func (s CreateViewStatement) String() (q string) {
-	if s.SourceName() == "" {
-		return
+func (s CreateViewStatement) String() string {
+	if s.SourceName() == "" || !s.View.valid() {
+		return ""
 	}
-	q = "CREATE "
+	var buf bytes.Buffer
+	buf.WriteString("CREATE ")
 	if s.ReplaceMode() {
-		q += "OR REPLACE "
+		buf.WriteString("OR REPLACE ")
 	}
-	q += "VIEW " + s.SourceName()
+	buf.WriteString("VIEW ")
+	buf.WriteString(quoteName(s.SourceName()))
 
 	// Concatenates field names.
-	cols := s.Columns()
-	if size := len(cols); size > 0 {
-		q += " ("
+	if cols := s.Columns(); len(cols) > 0 {
+		buf.WriteString(" (")
 		for i, c := range cols {
 			if i > 0 {
-				q += ", "
+				buf.WriteString(", ")
 			}
-			q += c.Name()
+			buf.WriteString(quoteName(c.Name()))
 		}
-		q += ")"
+		buf.WriteByte(')')
 	}
 
-	// Adds the data source.
-	v := s.View.String()
-	if v == "" {
-		return ""
-	}
-	q += " AS " + v
+	// Adds the data source, with its extended grammar (aggregate functions, ordering, etc.).
+	buf.WriteString(" AS ")
+	s.View.writeTo(&buf)
 
-	return
+	return buf.String()
 }
 
 // String outputs a describe statement.
-func (s DescribeStatement) String() (q string) {
+func (s DescribeStatement) String() string {
 	if s.SourceName() == "" {
-		return
+		return ""
 	}
-	q = "DESC "
+	var buf bytes.Buffer
+	buf.WriteString("DESC ")
 	if s.FullMode() {
-		q += "FULL "
+		buf.WriteString("FULL ")
 	}
-	q += s.SourceName()
+	buf.WriteString(quoteName(s.SourceName()))
 
-	cols := s.Columns()
-	if len(cols) == 1 {
-		q += " " + cols[0].Name()
+	if cols := s.Columns(); len(cols) == 1 {
+		buf.WriteByte(' ')
+		buf.WriteString(quoteName(cols[0].Name()))
 	}
 
-	return
+	return buf.String()
+}
+
+// String outputs a select statement with all its clauses, as parsed, without its terminator.
+// Use FullString to also get the terminator, as the G modifier,
+// and LegacyString to get the query expected by the Adwords API.
+func (s SelectStatement) String() string {
+	if !s.valid() {
+		return ""
+	}
+	var buf bytes.Buffer
+	s.writeTo(&buf)
+	return buf.String()
+}
+
+// valid returns true if the select statement has enough data to be output.
+func (s SelectStatement) valid() bool {
+	return len(s.Columns()) > 0 && s.SourceName() != ""
 }
 
-// String outputs a select statement.
-func (s SelectStatement) String() (q string) {
-	if len(s.Columns()) == 0 || s.SourceName() == "" {
-		return
+// writeTo writes the select statement in the buffer.
+func (s SelectStatement) writeTo(buf *bytes.Buffer) {
+	buf.WriteString("SELECT ")
+	if s.Distinct() {
+		buf.WriteString("DISTINCT ")
 	}
-	q = "SELECT "
 
 	// Adds columns.
 	for i, c := range s.Columns() {
 		if i > 0 {
-			q += ", "
+			buf.WriteString(", ")
 		}
-		// Distinct value.
-		var s string
-		if c.Distinct() {
-			s = "DISTINCT "
-		}
-		s += c.Name()
-		// Method name.
-		if method, ok := c.UseFunction(); ok {
-			s = method + "(" + s + ")"
-		}
-		// Alias.
-		if c.Alias() != "" {
-			s += " AS " + c.Alias()
-		}
-		q += s
+		buf.WriteString(FormatOptions{}.field(c))
+	}
+	if e := s.exceptString(); e != "" {
+		buf.WriteString(" EXCEPT (")
+		buf.WriteString(e)
+		buf.WriteString(")")
 	}
 
 	// Adds data source name.
-	q += " FROM " + s.SourceName()
-	q += s.whereString()
-	q += s.duringString()
+	buf.WriteString(" FROM ")
+	buf.WriteString(quoteName(s.SourceName()))
+	s.writeWhere(buf)
+	s.writeDuring(buf)
 
 	// Adds group by clause.
-	g := s.GroupList()
-	if gs := len(g); gs > 0 {
-		q += " GROUP BY "
-		for i := 0; i < gs; i++ {
-			if i > 0 {
-				q += ", "
-			}
-			q += strconv.Itoa(g[i].Position())
-		}
+	if len(s.GroupList()) > 0 {
+		buf.WriteString(" GROUP BY ")
+		s.writeGroup(buf)
 	}
 
 	// Adds sort orders.
-	o := s.OrderList()
-	if os := len(o); os > 0 {
-		q += " ORDER BY "
-		for i := 0; i < os; i++ {
-			if i > 0 {
-				q += ", "
-			}
-			q += strconv.Itoa(o[i].Position())
-			if o[i].SortDescending() {
-				q += " DESC"
-			}
-		}
+	if len(s.OrderList()) > 0 {
+		buf.WriteString(" ORDER BY ")
+		s.writeOrder(buf)
 	}
 
 	// Adds limit clause.
-	if rc, ok := s.PageSize(); ok {
-		q += " LIMIT "
-		if si := s.StartIndex(); si > 0 {
-			q += strconv.Itoa(si) + ", "
-		}
-		q += strconv.Itoa(rc)
+	if _, ok := s.PageSize(); ok {
+		buf.WriteString(" LIMIT ")
+		s.writeLimit(buf)
 	}
-
-	return
 }
 
 // LegacyString outputs a select statement as expected by Google Adwords.
 // Indeed, aggregate functions, ORDER BY, GROUP BY and LIMIT are not supported for reports.
-func (s SelectStatement) LegacyString() (q string) {
-	if len(s.Columns()) == 0 || s.SourceName() == "" {
-		return
+// It outputs the legal statement returned by Legalize, without the G modifier.
+func (s SelectStatement) LegacyString() string {
+	legal, _ := s.legalize()
+	return legal.String()
+}
+
+// modifierString outputs the G modifier if the vertical output is required.
+func (s Statement) modifierString() string {
+	if s.VerticalOutput() {
+		return "\\G"
 	}
-	q = "SELECT "
+	return ""
+}
 
-	// Concatenates selected fields.
-	for i, c := range s.Columns() {
+// conditionString outputs a condition of the where clause.
+func conditionString(c Condition) string {
+	var buf bytes.Buffer
+	writeCondition(&buf, c)
+	return buf.String()
+}
+
+// writeCondition writes a condition of the where clause in the buffer.
+func writeCondition(buf *bytes.Buffer, c Condition) {
+	buf.WriteString(quoteName(c.Name()))
+	buf.WriteByte(' ')
+	buf.WriteString(strings.ToUpper(c.Operator()))
+
+	val, lit := c.Value()
+	value := func(v string) {
+		buf.WriteByte(' ')
+		if lit {
+			buf.WriteString(v)
+		} else {
+			buf.WriteString(quoteString(v))
+		}
+	}
+	// The value of a list operator stays a list, except the placeholder of a fingerprint.
+	if len(val) > 1 || (isListOperator(c.OperatorToken()) && !(lit && val[0] == placeholder)) {
+		buf.WriteString(" [")
+		for y, v := range val {
+			if y > 0 {
+				buf.WriteString(" ,")
+			}
+			value(v)
+		}
+		buf.WriteString(" ]")
+	} else {
+		value(val[0])
+	}
+}
+
+// writeWhere writes the where clause in the buffer.
+func (s SelectStatement) writeWhere(buf *bytes.Buffer) {
+	for i, c := range s.ConditionList() {
 		if i > 0 {
-			q += ", "
+			buf.WriteString(" AND ")
+		} else {
+			buf.WriteString(" WHERE ")
 		}
-		q += c.Name()
+		writeCondition(buf, c)
 	}
+}
 
-	// Adds data source name.
-	q += " FROM " + s.SourceName()
-	q += s.whereString()
-	q += s.duringString()
+// writeDuring writes the during clause in the buffer.
+func (s SelectStatement) writeDuring(buf *bytes.Buffer) {
+	if d := s.duringValue(); d != "" {
+		buf.WriteString(" DURING ")
+		buf.WriteString(d)
+	}
+}
+
+// duringValue outputs the date range of the during clause.
+func (s SelectStatement) duringValue() (q string) {
+	d := s.DuringList()
+	if ds := len(d); ds == 2 {
+		q = d[0] + "," + d[1]
+	} else if ds > 0 {
+		// Literal range date
+		q = d[0]
+	}
 
 	return
 }
 
-// duringString outputs a where clause.
-func (s SelectStatement) whereString() (q string) {
-	if len(s.ConditionList()) > 0 {
-		q += " WHERE "
-		for i, c := range s.ConditionList() {
-			if i > 0 {
-				q += " AND "
-			}
-			q += c.Name() + " " + c.Operator()
-			val, lit := c.Value()
-			if len(val) > 1 {
-				q += " ["
-				for y, v := range val {
-					if y > 0 {
-						q += " ,"
-					}
-					if lit {
-						q += " " + v
-					} else {
-						q += " " + strconv.Quote(v)
-					}
-				}
-				q += " ]"
-			} else if lit {
-				q += " " + val[0]
-			} else {
-				q += " " + strconv.Quote(val[0])
-			}
+// exceptString outputs the names of the excluded columns.
+func (s SelectStatement) exceptString() string {
+	list := s.ExcludedList()
+	names := make([]string, len(list))
+	for i, c := range list {
+		names[i] = quoteName(c.Name())
+	}
+	return strings.Join(names, ", ")
+}
+
+// groupString outputs the column positions of the group by clause.
+func (s SelectStatement) groupString() string {
+	var buf bytes.Buffer
+	s.writeGroup(&buf)
+	return buf.String()
+}
+
+// writeGroup writes the column positions of the group by clause in the buffer,
+// followed by its rollup modifier.
+func (s SelectStatement) writeGroup(buf *bytes.Buffer) {
+	for i, g := range s.GroupList() {
+		if i > 0 {
+			buf.WriteString(", ")
 		}
+		buf.WriteString(strconv.Itoa(g.Position()))
+	}
+	if s.WithRollup() && len(s.GroupList()) > 0 {
+		buf.WriteString(" WITH ROLLUP")
 	}
+}
 
-	return
+// orderString outputs the column positions and sort orders of the order by clause.
+func (s SelectStatement) orderString() string {
+	var buf bytes.Buffer
+	s.writeOrder(&buf)
+	return buf.String()
 }
 
-// duringString outputs a during clause.
-func (s SelectStatement) duringString() (q string) {
-	d := s.DuringList()
-	if ds := len(d); ds > 0 {
-		q += " DURING "
-		if ds == 2 {
-			q += d[0] + "," + d[1]
-		} else {
-			// Literal range date
-			q += d[0]
+// writeOrder writes the column positions and sort orders of the order by clause in the buffer.
+func (s SelectStatement) writeOrder(buf *bytes.Buffer) {
+	for i, o := range s.OrderList() {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(strconv.Itoa(o.Position()))
+		if o.SortDescending() {
+			buf.WriteString(" DESC")
+		} else if o.ExplicitAsc() {
+			buf.WriteString(" ASC")
 		}
 	}
+}
 
-	return
+// limitString outputs the start index and the row count of the limit clause.
+func (s SelectStatement) limitString() string {
+	var buf bytes.Buffer
+	s.writeLimit(&buf)
+	return buf.String()
+}
+
+// writeLimit writes the start index and the row count of the limit clause in the buffer.
+func (s SelectStatement) writeLimit(buf *bytes.Buffer) {
+	if si, rc, ok := s.LimitRange(); ok {
+		if si > 0 || s.WithOffset {
+			buf.WriteString(strconv.Itoa(si))
+			buf.WriteString(", ")
+		}
+		buf.WriteString(strconv.Itoa(rc))
+	}
 }
 
 // String outputs a show statement.
-func (s ShowStatement) String() (q string) {
-	q = "SHOW "
+func (s ShowStatement) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("SHOW ")
 	if s.FullMode() {
-		q += "FULL "
-	}
-	q += "TABLES"
-
-	if p, used := s.LikePattern(); used {
-		var str string
-		switch {
-		case p.Equal != "":
-			str = p.Equal
-		case p.Contains != "":
-			str = "%" + p.Contains + "%"
-		case p.Prefix != "":
-			str = p.Prefix + "%"
-		case p.Suffix != "":
-			str = "%" + p.Suffix
+		buf.WriteString("FULL ")
+	}
+	buf.WriteString("TABLES")
+
+	if p, used := s.likeValue(); used {
+		buf.WriteString(" LIKE ")
+		buf.WriteString(p)
+	}
+	if str, used := s.withValue(); used {
+		buf.WriteString(" WITH ")
+		buf.WriteString(str)
+	}
+
+	return buf.String()
+}
+
+// likeValue outputs the quoted pattern of the like clause.
+func (s ShowStatement) likeValue() (string, bool) {
+	p, used := s.LikePattern()
+	if !used {
+		return "", false
+	}
+	return quoteString(p.String()), true
+}
+
+// withValue outputs the column name of the with clause, quoted if it is not an identifier.
+func (s ShowStatement) withValue() (string, bool) {
+	str, used := s.WithFieldName()
+	if used && !isIdentifier(str) {
+		str = quoteString(str)
+	}
+	return str, used
+}
+
+// String outputs a use statement.
+func (s UseStatement) String() string {
+	a := s.AccountID()
+	if a == "" {
+		return ""
+	}
+	var buf bytes.Buffer
+	buf.WriteString("USE ")
+	if isAccountNumber(a) {
+		buf.WriteString(a)
+	} else {
+		buf.WriteString(quoteString(a))
+	}
+
+	return buf.String()
+}
+
+// isAccountNumber returns true if the string is parsed as an unquoted account:
+// groups of digits separated by one dash, each group within the default size.
+func isAccountNumber(s string) bool {
+	for _, g := range strings.Split(s, "-") {
+		if g == "" || len(g) > DefaultMaxIdentifierBytes || strings.Trim(g, "0123456789") != "" {
+			return false
 		}
-		q += " LIKE " + strconv.Quote(str)
 	}
+	return true
+}
 
-	if str, used := s.WithFieldName(); used {
-		q += " WITH " + strconv.Quote(str)
+// isIdentifier returns true if the string is scanned as one identifier:
+// a letter followed by letters, digits or underscores, which is not a reserved word.
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		if (i == 0 && !isLetter(r)) || !isLiteral(r) {
+			return false
+		}
 	}
+	_, reserved := KeywordToken(s)
+	return s != "" && !reserved
+}
 
-	return
+// quoteName returns the name, between backticks if it is not scanned as an identifier:
+// a reserved word or a name with other runes than letters, digits or underscore.
+// The wildcard * is never quoted.
+func quoteName(s string) string {
+	if s == "" || s == "*" || isIdentifier(s) {
+		return s
+	}
+	return "`" + strings.Replace(s, "`", "``", -1) + "`"
+}
+
+// quoteString returns the string value between quotes, as expected by the scanner.
+// Single quotes are preferred, double quotes are used if the value only contains single quotes.
+// The backslashes and the quotes used inside the value are protected by a backslash.
+func quoteString(s string) string {
+	quote := byte('\'')
+	if strings.IndexByte(s, '\'') > -1 && strings.IndexByte(s, '"') < 0 {
+		quote = '"'
+	}
+	var buf bytes.Buffer
+	buf.WriteByte(quote)
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c == '\\' || c == quote {
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(s[i])
+	}
+	buf.WriteByte(quote)
+	return buf.String()
 }
@@ -1,6 +1,8 @@
 package awqlparse_test
 
 import (
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -21,19 +23,25 @@ func TestSelectStmt_String(t *testing.T) {
 			fq: `SHOW FULL TABLES`,
 		},
 		{
-			fq: `SHOW FULL TABLES LIKE "%rv"`,
+			fq: `SHOW FULL TABLES LIKE '%rv'`,
 		},
 		{
-			fq: `SHOW FULL TABLES LIKE "%rv%"`,
+			fq: `SHOW FULL TABLES LIKE '%rv%'`,
 		},
 		{
-			fq: `SHOW FULL TABLES LIKE "rv%"`,
+			fq: `SHOW FULL TABLES LIKE 'rv%'`,
 		},
 		{
-			fq: `SHOW TABLES LIKE "rv"`,
+			fq: `SHOW TABLES LIKE 'rv'`,
 		},
 		{
-			fq: `SHOW TABLES WITH "rv"`,
+			fq: `SHOW TABLES LIKE 'r%v%'`,
+		},
+		{
+			fq: `SHOW TABLES WITH rv`,
+		},
+		{
+			fq: `SHOW TABLES WITH ''`,
 		},
 		{
 			fq: `CREATE VIEW rv AS SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT LIMIT 10`,
@@ -48,8 +56,8 @@ func TestSelectStmt_String(t *testing.T) {
 			fq: `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT`,
 		},
 		{
-			fq: `SELECT SUM(Cost) AS c FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus = "ENABLED"`,
-			tq: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus = "ENABLED"`,
+			fq: `SELECT SUM(Cost) AS c FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus = 'ENABLED'`,
+			tq: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus = 'ENABLED'`,
 		},
 		{
 			fq: `SELECT CampaignName, Cost FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1 ORDER BY 2 DESC`,
@@ -82,3 +90,220 @@ func TestSelectStmt_String(t *testing.T) {
 		}
 	}
 }
+
+func TestUseStmt_String(t *testing.T) {
+	var tests = []struct {
+		fq, tq string
+	}{
+		{fq: `USE 123-456-7890`, tq: `USE 123-456-7890`},
+		{fq: `use 1234567890;`, tq: `USE 1234567890`},
+		{fq: `USE '123-456-7890'`, tq: `USE 123-456-7890`},
+		{fq: `USE '123-'`, tq: `USE '123-'`},
+		{fq: `USE '-123'`, tq: `USE '-123'`},
+		{fq: `USE '123--456'`, tq: `USE '123--456'`},
+		{fq: `USE '123.4'`, tq: `USE '123.4'`},
+		{fq: `USE "rv"`, tq: `USE 'rv'`},
+	}
+
+	for i, qt := range tests {
+		stmt, err := awql.ParseUseString(qt.fq)
+		if err != nil {
+			t.Fatalf("%d. Expected no error with '%v', received %v", i, qt.fq, err)
+		}
+		if q := stmt.String(); q != qt.tq {
+			t.Errorf("%d. Expected the query '%v' with '%s', received '%v'", i, qt.tq, qt.fq, q)
+		}
+		// Parsing the output must give the same account.
+		rStmt, err := awql.ParseUseString(stmt.String())
+		if err != nil {
+			t.Fatalf("%d. Expected no error with '%v', received %v", i, stmt, err)
+		}
+		if rStmt.AccountID() != stmt.AccountID() {
+			t.Errorf("%d. Expected the account %q with '%v', received %q", i, stmt.AccountID(), qt.tq, rStmt.AccountID())
+		}
+	}
+}
+
+func TestSelectStmt_QuotedValues(t *testing.T) {
+	var tests = []struct {
+		fq, tq string
+	}{
+		{fq: `SELECT Cost FROM R WHERE Name = "rv"`, tq: `SELECT Cost FROM R WHERE Name = 'rv'`},
+		{fq: `SELECT Cost FROM R WHERE Name = "O'Brien"`, tq: `SELECT Cost FROM R WHERE Name = "O'Brien"`},
+		{fq: `SELECT Cost FROM R WHERE Name = 'O\'Brien'`, tq: `SELECT Cost FROM R WHERE Name = "O'Brien"`},
+		{fq: `SELECT Cost FROM R WHERE Name = 'say "hi"'`, tq: `SELECT Cost FROM R WHERE Name = 'say "hi"'`},
+		{fq: `SELECT Cost FROM R WHERE Name = "say \"hi\""`, tq: `SELECT Cost FROM R WHERE Name = 'say "hi"'`},
+		{fq: `SELECT Cost FROM R WHERE Name = "it's \"rv\""`, tq: `SELECT Cost FROM R WHERE Name = 'it\'s "rv"'`},
+		{fq: `SELECT Cost FROM R WHERE Name = 'a\d\\'`, tq: `SELECT Cost FROM R WHERE Name = 'a\\d\\'`},
+		{fq: `SELECT Cost FROM R WHERE Name = 'C:\\rv'`, tq: `SELECT Cost FROM R WHERE Name = 'C:\\rv'`},
+		{fq: `SELECT Cost FROM R WHERE Name IN ["a'b", 'c']`, tq: `SELECT Cost FROM R WHERE Name IN [ "a'b" , 'c' ]`},
+	}
+
+	for i, qt := range tests {
+		stmt, err := awql.NewParser(strings.NewReader(qt.fq)).ParseSelect()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with '%v', received %v", i, qt.fq, err)
+		}
+		if q := stmt.String(); q != qt.tq {
+			t.Errorf("%d. Expected the query '%v' with '%s', received '%v'", i, qt.tq, qt.fq, q)
+		}
+		// Parsing the output must give the same statement.
+		rStmt, err := awql.NewParser(strings.NewReader(stmt.String())).ParseSelect()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with '%v', received %v", i, stmt, err)
+		}
+		if !reflect.DeepEqual(stmt, rStmt) {
+			t.Errorf("%d. Expected %#v with '%v', received %#v", i, stmt, qt.tq, rStmt)
+		}
+	}
+}
+
+func TestSelectStmt_QuotedBothValue(t *testing.T) {
+	stmt := awql.SelectStatement{
+		DataStatement: awql.DataStatement{
+			Fields:    []awql.DynamicField{awql.NewDynamicColumn(awql.NewColumn("Cost", ""), "", false)},
+			TableName: "R",
+		},
+		Where: []awql.Condition{
+			&awql.Where{Column: awql.NewColumn("Name", ""), Sign: "=", ColumnValue: []string{`it's "rv"\`}},
+		},
+	}
+	if q, tq := stmt.String(), `SELECT Cost FROM R WHERE Name = 'it\'s "rv"\\'`; q != tq {
+		t.Errorf("Expected the query '%v', received '%v'", tq, q)
+	}
+}
+
+func TestSelectStmt_RoundTrip(t *testing.T) {
+	var tests = []string{
+		`SELECT SUM(DISTINCT Cost) AS total FROM CAMPAIGN_PERFORMANCE_REPORT`,
+		`SELECT CampaignName name, Cost FROM CAMPAIGN_PERFORMANCE_REPORT`,
+		`SELECT CampaignName, COUNT(*) AS nb, MAX(Cost) FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1 ORDER BY 2 DESC LIMIT 15, 5\G`,
+		`SELECT DISTINCT Cost AS c FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId IN [123456789,987654321] DURING 20161224,20161224 ORDER BY 1 DESC LIMIT 5;`,
+		`SELECT Date, Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus IN ["ENABLED","PAUSED"] AND Clicks > 0 DURING LAST_WEEK GROUP BY Date\g`,
+		`SELECT Criteria FROM CRITERIA_PERFORMANCE_REPORT WHERE IsNegative = true AND Criteria != "true"`,
+		`SELECT DISTINCT CampaignName, COUNT(DISTINCT AdGroupId) FROM ADGROUP_PERFORMANCE_REPORT GROUP BY 1`,
+	}
+
+	for i, q := range tests {
+		stmt, err := awql.NewParser(strings.NewReader(q)).ParseSelect()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with '%v', received %v", i, q, err)
+		}
+		rq := stmt.FullString()
+		rStmt, err := awql.NewParser(strings.NewReader(rq)).ParseSelect()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with '%v', received %v", i, rq, err)
+		}
+		if !reflect.DeepEqual(stmt, rStmt) {
+			t.Errorf("%d. Expected %#v with '%v', received %#v", i, stmt, rq, rStmt)
+		}
+	}
+}
+
+func TestDescribeStmt_String(t *testing.T) {
+	var tests = []struct {
+		fq, tq string
+	}{
+		{fq: `DESCRIBE CAMPAIGN_PERFORMANCE_REPORT`, tq: `DESC CAMPAIGN_PERFORMANCE_REPORT`},
+		{fq: `describe full CAMPAIGN_PERFORMANCE_REPORT CampaignName;`, tq: `DESC FULL CAMPAIGN_PERFORMANCE_REPORT CampaignName`},
+		{fq: `DESC CAMPAIGN_PERFORMANCE_REPORT\G`, tq: `DESC CAMPAIGN_PERFORMANCE_REPORT`},
+	}
+
+	for i, qt := range tests {
+		stmt, err := awql.NewParser(strings.NewReader(qt.fq)).ParseDescribe()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with '%v', received %v", i, qt.fq, err)
+		}
+		if q := stmt.String(); q != qt.tq {
+			t.Errorf("%d. Expected the query '%v' with '%s', received '%v'", i, qt.tq, qt.fq, q)
+		}
+	}
+	// Without source, no query.
+	if q := (awql.DescribeStatement{}).String(); q != "" {
+		t.Errorf("Expected no query without table name, received '%v'", q)
+	}
+}
+
+func TestShowStmt_String(t *testing.T) {
+	var tests = []string{
+		`SHOW TABLES`,
+		`SHOW FULL TABLES\G`,
+		`SHOW TABLES LIKE 'CAMPAIGN%'`,
+		`SHOW TABLES LIKE '%REPORT'`,
+		`SHOW TABLES LIKE '%NEGATIVE%'`,
+		`SHOW TABLES LIKE 'LABEL'`,
+		`SHOW TABLES LIKE 'CAMPAIGN%PERFORMANCE%REPORT'`,
+		`SHOW FULL TABLES WITH CampaignName;`,
+		`SHOW TABLES WITH "CampaignName"`,
+		`SHOW TABLES WITH ""`,
+		`SHOW TABLES WITH "select"`,
+	}
+
+	for i, q := range tests {
+		stmt, err := awql.NewParser(strings.NewReader(q)).ParseShow()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with '%v', received %v", i, q, err)
+		}
+		// Parsing the output must give the same statement.
+		rq := stmt.FullString()
+		rStmt, err := awql.NewParser(strings.NewReader(rq)).ParseShow()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with '%v', received %v", i, rq, err)
+		}
+		if !reflect.DeepEqual(stmt, rStmt) {
+			t.Errorf("%d. Expected %#v with '%v', received %#v", i, stmt, rq, rStmt)
+		}
+	}
+}
+
+func TestCreateViewStmt_String(t *testing.T) {
+	var tests = []string{
+		`CREATE VIEW CAMPAIGN_DAILY AS SELECT SUM(DISTINCT Cost) FROM CAMPAIGN_PERFORMANCE_REPORT`,
+		`CREATE OR REPLACE VIEW CAMPAIGN_DAILY (Date, Adspend) AS SELECT Date, SUM(DISTINCT Cost) FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1`,
+		`CREATE VIEW rv AS SELECT CampaignName, MAX(Cost) AS c FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus IN ["ENABLED","PAUSED"] AND Impressions > 10 DURING LAST_7_DAYS GROUP BY 1 ORDER BY 2 DESC LIMIT 5, 10`,
+		`CREATE VIEW rv (Name) AS SELECT DISTINCT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT DURING 20161224,20161225 LIMIT 10`,
+		`CREATE VIEW rv AS SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT\G`,
+	}
+
+	for i, q := range tests {
+		stmt, err := awql.NewParser(strings.NewReader(q)).ParseCreateView()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with '%v', received %v", i, q, err)
+		}
+		rq := stmt.FullString()
+		rStmt, err := awql.NewParser(strings.NewReader(rq)).ParseCreateView()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with '%v', received %v", i, rq, err)
+		}
+		if !reflect.DeepEqual(stmt, rStmt) {
+			t.Errorf("%d. Expected %#v with '%v', received %#v", i, stmt, rq, rStmt)
+		}
+	}
+}
+
+// bigSelectQuery returns a select statement with 200 columns and 50 conditions.
+func bigSelectQuery() string {
+	cols := make([]string, 200)
+	for i := range cols {
+		cols[i] = "Col" + strconv.Itoa(i)
+	}
+	conds := make([]string, 50)
+	for i := range conds {
+		conds[i] = "Col" + strconv.Itoa(i) + " IN ['a', 'b', 'c', 'd', 'e']"
+	}
+	return "SELECT " + strings.Join(cols, ", ") +
+		" FROM CAMPAIGN_PERFORMANCE_REPORT WHERE " + strings.Join(conds, " AND ") +
+		" DURING YESTERDAY ORDER BY 1 DESC LIMIT 10"
+}
+
+func BenchmarkSelectStmt_String(b *testing.B) {
+	q := bigSelectQuery()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = stmts[0].String()
+	}
+}
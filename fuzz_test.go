@@ -0,0 +1,77 @@
+//go:build go1.18
+// +build go1.18
+
+package awqlparse_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+// fuzzSeeds lists queries used as seed corpus of the fuzz targets.
+var fuzzSeeds = []string{
+	``,
+	`SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT ORDER BY 1 LIMIT 5\GDESC ADGROUP_PERFORMANCE_REPORT AdGroupName;`,
+	`SELECT CampaignId, SUM(DISTINCT Cost) AS c FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus IN ["ENABLED","PAUSED"] AND Clicks > 0 DURING LAST_7_DAYS GROUP BY 1 ORDER BY 2 DESC LIMIT 5, 10;`,
+	`SELECT COUNT(*), MAX(Cost) FROM REPORT WHERE Name STARTS_WITH_IGNORE_CASE 'r\'v' DURING 20161224,20161225`,
+	"SELECT `group`, SUM(`a``b`) FROM `order` WHERE `group` > 0 GROUP BY `group` ORDER BY 2",
+	`CREATE OR REPLACE VIEW rv (day, cost) AS SELECT Date, Cost FROM CAMPAIGN_PERFORMANCE_REPORT`,
+	`DESC FULL CAMPAIGN_PERFORMANCE_REPORT CampaignName\g`,
+	`SHOW FULL TABLES LIKE "CAMPAIGN%"; SHOW TABLES WITH CampaignName`,
+	`USE 123-456-7890;`,
+	`SELECT Cost FROM REPORT WHERE CampaignId IN [`,
+	`SELECT Cost FROM REPORT WHERE Name = "rv\`,
+	`SELECT Cost FROM REPORT WHERE Cost > 1.2.3 LIMIT 99999999999999999999`,
+	"\ufeffSELECT\r\nCost\tFROM REPORT WHERE Nom = 'é'",
+}
+
+func FuzzParse(f *testing.F) {
+	for _, q := range fuzzSeeds {
+		f.Add(q)
+	}
+	f.Fuzz(func(t *testing.T, q string) {
+		stmts, err := awql.ParseString(q)
+		if err == nil {
+			for _, stmt := range stmts {
+				_ = stmt.String()
+			}
+		}
+		// Each call consumes at least one statement.
+		p := awql.NewParser(strings.NewReader(q))
+		for i := 0; ; i++ {
+			if _, err := p.ParseNext(); err == io.EOF {
+				break
+			}
+			if i > len(q) {
+				t.Fatalf("Expected the end of %q after %d statements", q, i)
+			}
+		}
+		if _, err := awql.Complete(q, len(q)); err != nil {
+			t.Errorf("Expected no error to complete %q, received %v", q, err)
+		}
+	})
+}
+
+func FuzzParseSelect(f *testing.F) {
+	for _, q := range fuzzSeeds {
+		f.Add(q)
+	}
+	f.Fuzz(func(t *testing.T, q string) {
+		stmt, err := awql.ParseSelectString(q)
+		if err != nil {
+			return
+		}
+		// The output must be parsed as the same statement.
+		s := stmt.String()
+		rs, err := awql.ParseSelectString(s)
+		if err != nil {
+			t.Fatalf("Expected to parse %q, the output of %q, received %v", s, q, err)
+		}
+		if rs.String() != s {
+			t.Fatalf("Expected %q as output of %q, received %q", s, q, rs.String())
+		}
+	})
+}
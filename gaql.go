@@ -0,0 +1,183 @@
+package awqlparse
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Field of the Google Ads Query Language used to filter by date.
+const gaqlDateField = "segments.date"
+
+// gaqlDateRanges maps the AWQL date range literals to their GAQL name.
+// The other ones, as ALL_TIME or the registered literals, have no equivalent.
+var gaqlDateRanges = map[string]string{
+	"TODAY":               "TODAY",
+	"YESTERDAY":           "YESTERDAY",
+	"THIS_WEEK_SUN_TODAY": "THIS_WEEK_SUN_TODAY",
+	"THIS_WEEK_MON_TODAY": "THIS_WEEK_MON_TODAY",
+	"LAST_WEEK":           "LAST_WEEK_MON_SUN",
+	"LAST_7_DAYS":         "LAST_7_DAYS",
+	"LAST_14_DAYS":        "LAST_14_DAYS",
+	"LAST_30_DAYS":        "LAST_30_DAYS",
+	"LAST_BUSINESS_WEEK":  "LAST_BUSINESS_WEEK",
+	"LAST_WEEK_SUN_SAT":   "LAST_WEEK_SUN_SAT",
+	"THIS_MONTH":          "THIS_MONTH",
+	"LAST_MONTH":          "LAST_MONTH",
+}
+
+// ToGAQL converts the select statement into the Google Ads Query Language.
+// The report and column names are translated with the given mapping,
+// the during clause becomes a condition on the segments.date field.
+// Aggregate functions, distinct fields, aliases, GROUP BY clause, offset of the
+// LIMIT clause, date range literals without GAQL name, placeholders of the during
+// clause and names without mapping have no equivalent in GAQL:
+// if the statement uses them, an error lists all of them.
+func ToGAQL(stmt SelectStmt, fieldMap map[string]string) (string, error) {
+	var unmappable []string
+	name := func(s string) string {
+		n, ok := fieldMap[s]
+		if !ok || n == "" {
+			unmappable = append(unmappable, s)
+		}
+		return n
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT ")
+	if stmt.Distinct() {
+		unmappable = append(unmappable, "DISTINCT")
+	}
+	for i, c := range stmt.Columns() {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(name(c.Name()))
+		method, distinct, ok := c.Aggregate()
+		if ok {
+			unmappable = append(unmappable, method+"("+c.Name()+")")
+		}
+		if distinct {
+			unmappable = append(unmappable, "DISTINCT "+c.Name())
+		}
+		if alias, ok := c.Alias(); ok {
+			unmappable = append(unmappable, "AS "+alias)
+		}
+	}
+	buf.WriteString(" FROM ")
+	buf.WriteString(name(stmt.SourceName()))
+
+	// Adds the conditions, with the date range as last one.
+	var conds []string
+	for _, c := range stmt.ConditionList() {
+		q, ok := gaqlCondition(name(c.Name()), c)
+		if !ok {
+			unmappable = append(unmappable, c.Name()+" "+c.Operator())
+		}
+		conds = append(conds, q)
+	}
+	if d := stmt.DuringList(); len(d) == 2 {
+		if !isDate(d[0]) || !isDate(d[1]) {
+			unmappable = append(unmappable, "DURING "+strings.Join(d, ","))
+		}
+		conds = append(conds, gaqlDateField+" BETWEEN '"+isoDate(d[0])+"' AND '"+isoDate(d[1])+"'")
+	} else if len(d) == 1 {
+		r, ok := gaqlDateRanges[strings.ToUpper(d[0])]
+		if !ok {
+			unmappable = append(unmappable, "DURING "+d[0])
+		}
+		conds = append(conds, gaqlDateField+" DURING "+r)
+	}
+	if len(conds) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(conds, " AND "))
+	}
+
+	if len(stmt.GroupList()) > 0 {
+		unmappable = append(unmappable, "GROUP BY")
+	}
+	for i, o := range stmt.OrderList() {
+		if i > 0 {
+			buf.WriteString(", ")
+		} else {
+			buf.WriteString(" ORDER BY ")
+		}
+		buf.WriteString(name(o.Name()))
+		if o.SortDescending() {
+			buf.WriteString(" DESC")
+		} else {
+			buf.WriteString(" ASC")
+		}
+	}
+	if rc, ok := stmt.PageSize(); ok {
+		if stmt.StartIndex() > 0 {
+			unmappable = append(unmappable, "LIMIT offset")
+		}
+		buf.WriteString(" LIMIT ")
+		buf.WriteString(strconv.Itoa(rc))
+	}
+
+	if len(unmappable) > 0 {
+		return "", NewXParserError(ErrMsgUnmappable, strings.Join(unmappable, ", "))
+	}
+	return buf.String(), nil
+}
+
+// gaqlCondition returns the condition in GAQL, using the given field name.
+// The second parameter is false if the operator has no equivalent or if the condition has no value.
+func gaqlCondition(name string, c Condition) (string, bool) {
+	val, lit := c.Value()
+	if len(val) == 0 {
+		return "", false
+	}
+	value := func(v string) string {
+		if lit {
+			return v
+		}
+		return quoteString(v)
+	}
+	list := func() string {
+		s := make([]string, len(val))
+		for i, v := range val {
+			s[i] = value(v)
+		}
+		return "(" + strings.Join(s, ", ") + ")"
+	}
+
+	switch op := strings.ToUpper(c.Operator()); op {
+	case "=", "!=", ">", ">=", "<", "<=":
+		return name + " " + op + " " + value(val[0]), true
+	case "IN":
+		return name + " IN " + list(), true
+	case "NOT_IN":
+		return name + " NOT IN " + list(), true
+	case "STARTS_WITH":
+		return name + " LIKE " + quoteString(likeEscape(val[0])+"%"), true
+	case "CONTAINS":
+		return name + " LIKE " + quoteString("%"+likeEscape(val[0])+"%"), true
+	case "DOES_NOT_CONTAIN":
+		return name + " NOT LIKE " + quoteString("%"+likeEscape(val[0])+"%"), true
+	case "STARTS_WITH_IGNORE_CASE":
+		return name + " REGEXP_MATCH " + quoteString("(?i)"+regexp.QuoteMeta(val[0])+".*"), true
+	case "CONTAINS_IGNORE_CASE":
+		return name + " REGEXP_MATCH " + quoteString("(?i).*"+regexp.QuoteMeta(val[0])+".*"), true
+	case "DOES_NOT_CONTAIN_IGNORE_CASE":
+		return name + " NOT REGEXP_MATCH " + quoteString("(?i).*"+regexp.QuoteMeta(val[0])+".*"), true
+	}
+	return "", false
+}
+
+// likeEscape protects the wildcards of the LIKE operator by brackets, as expected by GAQL.
+func likeEscape(s string) string {
+	r := strings.NewReplacer("[", "[[]", "]", "[]]", "%", "[%]", "_", "[_]")
+	return r.Replace(s)
+}
+
+// isoDate converts a date formatted as YYYYMMDD to YYYY-MM-DD.
+func isoDate(s string) string {
+	if len(s) != 8 {
+		return s
+	}
+	return s[:4] + "-" + s[4:6] + "-" + s[6:]
+}
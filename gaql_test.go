@@ -0,0 +1,119 @@
+package awqlparse_test
+
+import (
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+var gaqlFieldMap = map[string]string{
+	"CAMPAIGN_PERFORMANCE_REPORT": "campaign",
+	"CampaignId":                  "campaign.id",
+	"CampaignName":                "campaign.name",
+	"CampaignStatus":              "campaign.status",
+	"Clicks":                      "metrics.clicks",
+}
+
+func TestToGAQL(t *testing.T) {
+	var tests = []struct {
+		fq, tq string
+		err    bool
+	}{
+		{
+			fq: `SELECT CampaignId, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			tq: `SELECT campaign.id, metrics.clicks FROM campaign`,
+		},
+		{
+			fq: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus IN ["ENABLED","PAUSED"] AND Clicks > 10`,
+			tq: `SELECT campaign.id FROM campaign WHERE campaign.status IN ('ENABLED', 'PAUSED') AND metrics.clicks > 10`,
+		},
+		{
+			fq: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus NOT_IN [ENABLED, PAUSED]`,
+			tq: `SELECT campaign.id FROM campaign WHERE campaign.status NOT IN (ENABLED, PAUSED)`,
+		},
+		{
+			fq: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName DOES_NOT_CONTAIN "50%" AND CampaignName STARTS_WITH "rv"`,
+			tq: `SELECT campaign.id FROM campaign WHERE campaign.name NOT LIKE '%50[%]%' AND campaign.name LIKE 'rv%'`,
+		},
+		{
+			fq: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName CONTAINS_IGNORE_CASE "rv"`,
+			tq: `SELECT campaign.id FROM campaign WHERE campaign.name REGEXP_MATCH '(?i).*rv.*'`,
+		},
+		{
+			fq: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0 DURING 20161224,20161231`,
+			tq: `SELECT campaign.id FROM campaign WHERE metrics.clicks > 0 AND segments.date BETWEEN '2016-12-24' AND '2016-12-31'`,
+		},
+		{
+			fq: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING LAST_WEEK ORDER BY 1 DESC LIMIT 10`,
+			tq: `SELECT campaign.id FROM campaign WHERE segments.date DURING LAST_WEEK_MON_SUN ORDER BY campaign.id DESC LIMIT 10`,
+		},
+		{
+			fq:  `SELECT CampaignId, SUM(Clicks) FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1`,
+			err: true,
+		},
+		{
+			fq:  `SELECT CampaignId, Impressions FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			err: true,
+		},
+		{
+			fq:  `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT LIMIT 5, 10`,
+			err: true,
+		},
+		{
+			fq: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING last_30_days`,
+			tq: `SELECT campaign.id FROM campaign WHERE segments.date DURING LAST_30_DAYS`,
+		},
+		{
+			fq:  `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING ALL_TIME`,
+			err: true,
+		},
+		{
+			fq:  `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING @start, @end`,
+			err: true,
+		},
+	}
+
+	for i, tt := range tests {
+		stmts, err := awql.NewParser(strings.NewReader(tt.fq)).Parse()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.fq, err)
+		}
+		q, err := awql.ToGAQL(stmts[0].(awql.SelectStmt), gaqlFieldMap)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%d. Expected an error with %q, received %q", i, tt.fq, q)
+			}
+		} else if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.fq, err)
+		} else if q != tt.tq {
+			t.Errorf("%d. Expected %q with %q, received %q", i, tt.tq, tt.fq, q)
+		}
+	}
+}
+
+func TestToGAQL_Unmappable(t *testing.T) {
+	q := `SELECT CampaignId, SUM(Clicks) AS c FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	_, err = awql.ToGAQL(stmts[0].(awql.SelectStmt), gaqlFieldMap)
+	if err == nil {
+		t.Fatalf("Expected an error with %q", q)
+	}
+	for _, s := range []string{"SUM(Clicks)", "AS c", "GROUP BY"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("Expected %q in the error, received %q", s, err.Error())
+		}
+	}
+
+	// A condition built without value.
+	stmt := &awql.SelectStatement{}
+	stmt.TableName = "CAMPAIGN_PERFORMANCE_REPORT"
+	stmt.Fields = []awql.DynamicField{awql.NewDynamicColumn(awql.NewColumn("CampaignId", ""), "", false)}
+	stmt.Where = []awql.Condition{&awql.Where{Column: awql.NewColumn("Clicks", ""), Sign: ">"}}
+	if _, err = awql.ToGAQL(stmt, gaqlFieldMap); err == nil || !strings.Contains(err.Error(), "Clicks >") {
+		t.Errorf("Expected an error with the condition without value, received %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package awqlparse
+
+import (
+	"sort"
+	"strings"
+)
+
+// keywords maps the reserved words, in upper case, to their token.
+var keywords = map[string]Token{
+	"DESCRIBE":                     DESCRIBE,
+	"SELECT":                       SELECT,
+	"CREATE":                       CREATE,
+	"REPLACE":                      REPLACE,
+	"VIEW":                         VIEW,
+	"SHOW":                         SHOW,
+	"USE":                          USE,
+	"FULL":                         FULL,
+	"TABLES":                       TABLES,
+	"DISTINCT":                     DISTINCT,
+	"AS":                           AS,
+	"FROM":                         FROM,
+	"WHERE":                        WHERE,
+	"LIKE":                         LIKE,
+	"WITH":                         WITH,
+	"AND":                          AND,
+	"OR":                           OR,
+	"IN":                           IN,
+	"NOT_IN":                       NOT_IN,
+	"STARTS_WITH":                  STARTS_WITH,
+	"STARTS_WITH_IGNORE_CASE":      STARTS_WITH_IGNORE_CASE,
+	"CONTAINS":                     CONTAINS,
+	"CONTAINS_IGNORE_CASE":         CONTAINS_IGNORE_CASE,
+	"DOES_NOT_CONTAIN":             DOES_NOT_CONTAIN,
+	"DOES_NOT_CONTAIN_IGNORE_CASE": DOES_NOT_CONTAIN_IGNORE_CASE,
+	"DURING":                       DURING,
+	"GROUP":                        GROUP,
+	"ORDER":                        ORDER,
+	"BY":                           BY,
+	"ASC":                          ASC,
+	"DESC":                         DESC,
+	"LIMIT":                        LIMIT,
+	"EXCEPT":                       EXCEPT,
+}
+
+// operators maps the operators of a condition to their literal.
+var operators = map[Token]string{
+	EQUAL:                        "=",
+	DIFFERENT:                    "!=",
+	SUPERIOR:                     ">",
+	SUPERIOR_OR_EQUAL:            ">=",
+	INFERIOR:                     "<",
+	INFERIOR_OR_EQUAL:            "<=",
+	IN:                           "IN",
+	NOT_IN:                       "NOT_IN",
+	STARTS_WITH:                  "STARTS_WITH",
+	STARTS_WITH_IGNORE_CASE:      "STARTS_WITH_IGNORE_CASE",
+	CONTAINS:                     "CONTAINS",
+	CONTAINS_IGNORE_CASE:         "CONTAINS_IGNORE_CASE",
+	DOES_NOT_CONTAIN:             "DOES_NOT_CONTAIN",
+	DOES_NOT_CONTAIN_IGNORE_CASE: "DOES_NOT_CONTAIN_IGNORE_CASE",
+}
+
+// functionNames lists the aggregate functions.
+var functionNames = []string{"AVG", "COUNT", "MAX", "MIN", "SUM"}
+
+// Keywords returns the reserved words, sorted in alphabetical order.
+func Keywords() []string {
+	list := make([]string, 0, len(keywords))
+	for k := range keywords {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// KeywordToken returns the token of the reserved word, whatever its case.
+// The boolean is false if the word is not reserved.
+func KeywordToken(s string) (Token, bool) {
+	tk, ok := keywords[strings.ToUpper(s)]
+	return tk, ok
+}
+
+// Operators returns the operators of a condition, sorted in alphabetical order.
+func Operators() []string {
+	list := make([]string, 0, len(operators))
+	for _, o := range operators {
+		list = append(list, o)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Functions returns the aggregate functions, sorted in alphabetical order.
+func Functions() []string {
+	return append([]string(nil), functionNames...)
+}
@@ -0,0 +1,60 @@
+package awqlparse_test
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestKeywords(t *testing.T) {
+	list := awql.Keywords()
+	if !sort.StringsAreSorted(list) {
+		t.Errorf("Expected sorted keywords, received %v", list)
+	}
+	for _, k := range list {
+		tk, ok := awql.KeywordToken(strings.ToLower(k))
+		if !ok {
+			t.Errorf("Expected a token for the keyword %v", k)
+			continue
+		}
+		// The token must round-trip through the table.
+		if tk.String() != k || !tk.IsKeyword() {
+			t.Errorf("Expected the token %v to be the keyword %v", tk, k)
+		}
+		// The scanner must use the same token.
+		if stk, _ := awql.NewScanner(strings.NewReader(k)).Scan(); stk != tk {
+			t.Errorf("Expected the token %d for %v, received %d", tk, k, stk)
+		}
+	}
+	if _, ok := awql.KeywordToken("CampaignName"); ok {
+		t.Error("Expected no token for a column name")
+	}
+}
+
+func TestOperators(t *testing.T) {
+	list := awql.Operators()
+	if len(list) != 14 {
+		t.Errorf("Expected 14 operators, received %v", list)
+	}
+	for _, o := range list {
+		tk, literal := awql.NewScanner(strings.NewReader(o)).Scan()
+		if literal != o || tk == awql.IDENTIFIER || tk == awql.ILLEGAL {
+			t.Errorf("Expected the operator %v to be scanned, received %d (%v)", o, tk, literal)
+		}
+	}
+}
+
+func TestFunctions(t *testing.T) {
+	list := awql.Functions()
+	if expected := []string{"AVG", "COUNT", "MAX", "MIN", "SUM"}; !reflect.DeepEqual(list, expected) {
+		t.Errorf("Expected %v, received %v", expected, list)
+	}
+	// The list is a copy.
+	list[0] = "RV"
+	if awql.Functions()[0] != "AVG" {
+		t.Error("Expected the functions to be immutable")
+	}
+}
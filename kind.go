@@ -0,0 +1,59 @@
+package awqlparse
+
+import "strconv"
+
+// Kind represents the kind of a statement.
+type Kind int
+
+// List of the kinds of statement.
+const (
+	KindUnknown Kind = iota
+	KindSelect
+	KindDescribe
+	KindShow
+	KindCreateView
+	KindUse
+)
+
+// kindNames lists the names of the kinds of statement.
+var kindNames = [...]string{
+	KindUnknown:    "UNKNOWN",
+	KindSelect:     "SELECT",
+	KindDescribe:   "DESCRIBE",
+	KindShow:       "SHOW",
+	KindCreateView: "CREATE VIEW",
+	KindUse:        "USE",
+}
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Kind returns the kind of the create view statement.
+func (s CreateViewStatement) Kind() Kind {
+	return KindCreateView
+}
+
+// Kind returns the kind of the describe statement.
+func (s DescribeStatement) Kind() Kind {
+	return KindDescribe
+}
+
+// Kind returns the kind of the select statement.
+func (s SelectStatement) Kind() Kind {
+	return KindSelect
+}
+
+// Kind returns the kind of the show statement.
+func (s ShowStatement) Kind() Kind {
+	return KindShow
+}
+
+// Kind returns the kind of the use statement.
+func (s UseStatement) Kind() Kind {
+	return KindUse
+}
@@ -0,0 +1,45 @@
+package awqlparse_test
+
+import (
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestStmt_Kind(t *testing.T) {
+	var tests = []struct {
+		q    string
+		kind awql.Kind
+	}{
+		{q: `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT`, kind: awql.KindSelect},
+		{q: `DESC CAMPAIGN_PERFORMANCE_REPORT`, kind: awql.KindDescribe},
+		{q: `SHOW TABLES`, kind: awql.KindShow},
+		{q: `CREATE VIEW rv AS SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT`, kind: awql.KindCreateView},
+		{q: `USE 123-456-7890`, kind: awql.KindUse},
+	}
+
+	// Every kind must be returned by a statement, except the unknown one.
+	covered := map[awql.Kind]bool{awql.KindUnknown: true}
+	for _, tt := range tests {
+		covered[tt.kind] = true
+	}
+	for k := awql.KindUnknown; !strings.HasPrefix(k.String(), "Kind("); k++ {
+		if !covered[k] {
+			t.Errorf("Expected a test parsing a statement of kind %v", k)
+		}
+	}
+
+	for i, tt := range tests {
+		stmts, err := awql.ParseString(tt.q)
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		}
+		if k := stmts[0].Kind(); k != tt.kind {
+			t.Errorf("%d. Expected %v with %q, received %v", i, tt.kind, tt.q, k)
+		}
+	}
+	if s := awql.Kind(42).String(); s != "Kind(42)" {
+		t.Errorf("Expected Kind(42), received %q", s)
+	}
+}
@@ -0,0 +1,86 @@
+package awqlparse
+
+// ClauseChange describes a part of a select statement removed to be supported by Adwords.
+// Clause is the removed keyword or aggregate function, Columns the names of the columns involved.
+type ClauseChange struct {
+	Clause  string
+	Columns []string
+}
+
+// Legalize returns a copy of the select statement without the constructs not supported by Adwords:
+// DISTINCT, EXCEPT, aggregate functions, aliases, GROUP BY with its WITH ROLLUP modifier, ORDER BY and LIMIT clauses.
+// It also returns the list of the removed parts, in order of appearance.
+// The DISTINCT of a field is listed with its column, the one of the statement without.
+func (s SelectStatement) Legalize() (SelectStmt, []ClauseChange) {
+	return s.legalize()
+}
+
+// legalize returns the legal copy of the select statement and the removed parts.
+func (s SelectStatement) legalize() (*SelectStatement, []ClauseChange) {
+	var removed []ClauseChange
+	legal := s.Clone()
+	if legal.Unique {
+		legal.Unique = false
+		removed = append(removed, ClauseChange{Clause: "DISTINCT"})
+	}
+	if len(legal.Excluded) > 0 {
+		removed = append(removed, ClauseChange{Clause: "EXCEPT", Columns: fieldNames(legal.Excluded)})
+		legal.Excluded = nil
+	}
+	var aliases []string
+	for i, f := range legal.Fields {
+		method, distinct, ok := f.Aggregate()
+		alias, aliased := f.Alias()
+		if !ok && !distinct && !aliased {
+			continue
+		}
+		if ok {
+			removed = append(removed, ClauseChange{Clause: method, Columns: []string{f.Name()}})
+		}
+		if distinct {
+			// Distinct field, unlike the DISTINCT of the statement, without column.
+			removed = append(removed, ClauseChange{Clause: "DISTINCT", Columns: []string{f.Name()}})
+		}
+		if aliased {
+			aliases = append(aliases, alias)
+		}
+		legal.Fields[i] = NewDynamicColumn(NewColumn(f.Name(), ""), "", false)
+	}
+	if len(aliases) > 0 {
+		removed = append(removed, ClauseChange{Clause: "AS", Columns: aliases})
+	}
+	if len(legal.GroupBy) > 0 {
+		var names []string
+		for _, g := range legal.GroupBy {
+			names = append(names, g.Name())
+		}
+		removed = append(removed, ClauseChange{Clause: "GROUP BY", Columns: names})
+		legal.GroupBy = nil
+	}
+	if legal.Rollup {
+		removed = append(removed, ClauseChange{Clause: "WITH ROLLUP"})
+		legal.Rollup = false
+	}
+	if len(legal.OrderBy) > 0 {
+		var names []string
+		for _, o := range legal.OrderBy {
+			names = append(names, o.Name())
+		}
+		removed = append(removed, ClauseChange{Clause: "ORDER BY", Columns: names})
+		legal.OrderBy = nil
+	}
+	if legal.WithRowCount {
+		legal.Limit = Limit{}
+		removed = append(removed, ClauseChange{Clause: "LIMIT"})
+	}
+	return legal, removed
+}
+
+// fieldNames returns the names of the fields.
+func fieldNames(list []Field) []string {
+	names := make([]string, len(list))
+	for i, f := range list {
+		names[i] = f.Name()
+	}
+	return names
+}
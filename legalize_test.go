@@ -0,0 +1,65 @@
+package awqlparse_test
+
+import (
+	"reflect"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestSelectStatement_Legalize(t *testing.T) {
+	var tests = []struct {
+		q       string
+		legal   string
+		removed []awql.ClauseChange
+	}{
+		{
+			q:     `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0 DURING YESTERDAY\G`,
+			legal: `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0 DURING YESTERDAY`,
+		},
+		{
+			q:     `SELECT DISTINCT CampaignName AS n, SUM(DISTINCT Clicks) AS c, COUNT(*) FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1 ORDER BY 2 DESC LIMIT 10, 5`,
+			legal: `SELECT CampaignName, Clicks, * FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			removed: []awql.ClauseChange{
+				{Clause: "DISTINCT"},
+				{Clause: "SUM", Columns: []string{"Clicks"}},
+				{Clause: "DISTINCT", Columns: []string{"Clicks"}},
+				{Clause: "COUNT", Columns: []string{"*"}},
+				{Clause: "AS", Columns: []string{"n", "c"}},
+				{Clause: "GROUP BY", Columns: []string{"CampaignName"}},
+				{Clause: "ORDER BY", Columns: []string{"Clicks"}},
+				{Clause: "LIMIT"},
+			},
+		},
+		{
+			q:     `SELECT CampaignName, Cost FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1 WITH ROLLUP`,
+			legal: `SELECT CampaignName, Cost FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			removed: []awql.ClauseChange{
+				{Clause: "GROUP BY", Columns: []string{"CampaignName"}},
+				{Clause: "WITH ROLLUP"},
+			},
+		},
+		{
+			q:       `SELECT * EXCEPT (Cost, Clicks) FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			legal:   `SELECT * FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			removed: []awql.ClauseChange{{Clause: "EXCEPT", Columns: []string{"Cost", "Clicks"}}},
+		},
+	}
+	for i, tt := range tests {
+		stmt, err := awql.ParseSelectString(tt.q)
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		}
+		legal, removed := stmt.Legalize()
+		if s := legal.String(); s != tt.legal {
+			t.Errorf("%d. Expected %q, received %q", i, tt.legal, s)
+		}
+		if !reflect.DeepEqual(removed, tt.removed) {
+			t.Errorf("%d. Expected %v, received %v", i, tt.removed, removed)
+		}
+		// The source statement is left unchanged.
+		if s := stmt.String(); s == legal.String() && len(tt.removed) > 0 {
+			t.Errorf("%d. Expected the source statement unchanged, received %q", i, s)
+		}
+	}
+}
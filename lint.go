@@ -0,0 +1,119 @@
+package awqlparse
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Codes of the warnings returned by Lint.
+const (
+	// WarnUngroupedColumn flags a field neither aggregated nor grouped
+	// in a statement using aggregate functions or a GROUP BY clause.
+	WarnUngroupedColumn = "UNGROUPED_COLUMN"
+	// WarnGroupedAggregate flags an aggregate function used in the GROUP BY clause.
+	WarnGroupedAggregate = "GROUPED_AGGREGATE"
+	// WarnDistinctAggregate flags a distinct field mixed with aggregate functions.
+	WarnDistinctAggregate = "DISTINCT_AGGREGATE"
+	// WarnDuplicateAlias flags an alias used by many fields.
+	WarnDuplicateAlias = "DUPLICATE_ALIAS"
+	// WarnDuplicateColumn flags a column selected many times without alias.
+	WarnDuplicateColumn = "DUPLICATE_COLUMN"
+	// WarnShadowedColumn flags an alias named as another selected column.
+	WarnShadowedColumn = "SHADOWED_COLUMN"
+)
+
+// Warning represents a semantic issue of a statement, that does not prevent its parsing.
+type Warning struct {
+	Code      string
+	Column    string
+	Positions []int
+}
+
+// String outputs the warning.
+func (w Warning) String() string {
+	return fmt.Sprintf("%v (%v at %v)", w.Code, w.Column, w.Positions)
+}
+
+// Lint checks the consistency of the aggregate functions with the other fields
+// and the GROUP BY clause of the select statement.
+// It also checks that each field has its own name in the output.
+func Lint(stmt SelectStmt) []Warning {
+	var (
+		aggregate bool
+		fields    = stmt.Columns()
+		warns     = lintNames(fields)
+		grouped   = make(map[int]bool)
+	)
+	for _, f := range fields {
+		if _, _, ok := f.Aggregate(); ok {
+			aggregate = true
+			break
+		}
+	}
+	for _, g := range stmt.GroupList() {
+		pos := g.Position()
+		grouped[pos] = true
+		if pos < 1 || pos > len(fields) {
+			continue
+		}
+		if _, _, ok := fields[pos-1].Aggregate(); ok {
+			warns = append(warns, Warning{Code: WarnGroupedAggregate, Column: fields[pos-1].Name(), Positions: []int{pos}})
+		}
+	}
+	if !aggregate && len(grouped) == 0 {
+		return warns
+	}
+	for i, f := range fields {
+		if _, _, ok := f.Aggregate(); ok {
+			continue
+		}
+		pos := i + 1
+		if stmt.Distinct() && aggregate {
+			warns = append(warns, Warning{Code: WarnDistinctAggregate, Column: f.Name(), Positions: []int{pos}})
+		}
+		if !grouped[pos] {
+			warns = append(warns, Warning{Code: WarnUngroupedColumn, Column: f.Name(), Positions: []int{pos}})
+		}
+	}
+	return warns
+}
+
+// lintNames returns the warnings about the fields sharing the same name in the output:
+// duplicate aliases, columns selected twice and aliases shadowing a column.
+func lintNames(fields []DynamicField) []Warning {
+	var (
+		warns   []Warning
+		names   []string
+		aliases = make(map[string][]int)
+		columns = make(map[string][]int)
+	)
+	for i, f := range fields {
+		name, ok := f.Alias()
+		if ok {
+			aliases[name] = append(aliases[name], i+1)
+		} else if _, _, ok = f.Aggregate(); !ok {
+			name = f.Name()
+			columns[name] = append(columns[name], i+1)
+		} else {
+			continue
+		}
+		if len(aliases[name])+len(columns[name]) == 1 {
+			// First use of this name.
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		if pos := aliases[name]; len(pos) > 1 {
+			warns = append(warns, Warning{Code: WarnDuplicateAlias, Column: name, Positions: pos})
+		}
+		if pos := columns[name]; len(pos) > 1 {
+			warns = append(warns, Warning{Code: WarnDuplicateColumn, Column: name, Positions: pos})
+		}
+		if pos, ok := columns[name]; ok && len(aliases[name]) > 0 {
+			pos = append(append([]int(nil), aliases[name]...), pos...)
+			sort.Ints(pos)
+			warns = append(warns, Warning{Code: WarnShadowedColumn, Column: name, Positions: pos})
+		}
+	}
+	return warns
+}
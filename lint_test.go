@@ -0,0 +1,77 @@
+package awqlparse_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestLint(t *testing.T) {
+	var tests = []struct {
+		q     string
+		warns []awql.Warning
+	}{
+		{q: `SELECT CampaignName, Cost FROM CAMPAIGN_PERFORMANCE_REPORT`},
+		{q: `SELECT SUM(Cost), COUNT(*) FROM CAMPAIGN_PERFORMANCE_REPORT`},
+		{q: `SELECT CampaignName, SUM(Cost) FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1`},
+		{q: `SELECT CampaignName AS n, SUM(Cost) FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY n`},
+		{
+			q: `SELECT CampaignName, SUM(Cost) FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			warns: []awql.Warning{
+				{Code: awql.WarnUngroupedColumn, Column: "CampaignName", Positions: []int{1}},
+			},
+		},
+		{
+			q: `SELECT CampaignId, CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1`,
+			warns: []awql.Warning{
+				{Code: awql.WarnUngroupedColumn, Column: "CampaignName", Positions: []int{2}},
+			},
+		},
+		{
+			q: `SELECT CampaignName, SUM(Cost) FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1, 2`,
+			warns: []awql.Warning{
+				{Code: awql.WarnGroupedAggregate, Column: "Cost", Positions: []int{2}},
+			},
+		},
+		{
+			q: `SELECT DISTINCT CampaignName, SUM(Cost) FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1`,
+			warns: []awql.Warning{
+				{Code: awql.WarnDistinctAggregate, Column: "CampaignName", Positions: []int{1}},
+			},
+		},
+		{q: `SELECT CampaignName AS n, SUM(Cost) AS c, SUM(Clicks) FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1`},
+		{
+			q: `SELECT Cost AS c, Clicks AS c, Impressions AS c FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			warns: []awql.Warning{
+				{Code: awql.WarnDuplicateAlias, Column: "c", Positions: []int{1, 2, 3}},
+			},
+		},
+		{
+			q: `SELECT Cost, Clicks, Cost FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			warns: []awql.Warning{
+				{Code: awql.WarnDuplicateColumn, Column: "Cost", Positions: []int{1, 3}},
+			},
+		},
+		{
+			q: `SELECT Cost, Clicks AS Cost FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			warns: []awql.Warning{
+				{Code: awql.WarnShadowedColumn, Column: "Cost", Positions: []int{1, 2}},
+			},
+		},
+		{
+			q: `SELECT Cost AS c, Cost FROM CAMPAIGN_PERFORMANCE_REPORT`,
+		},
+	}
+
+	for i, tt := range tests {
+		stmts, err := awql.NewParser(strings.NewReader(tt.q)).Parse()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		}
+		if warns := awql.Lint(stmts[0].(awql.SelectStmt)); !reflect.DeepEqual(warns, tt.warns) {
+			t.Errorf("%d. Expected %v with %q, received %v", i, tt.warns, tt.q, warns)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package awqlparse
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Literal represents a literal value of a statement, with its place in the statement.
+// Position is the position of the condition or date in its clause, starting at 1,
+// and Index the index of the value in the list of values of the condition.
+type Literal struct {
+	Clause   string
+	Column   string
+	Position int
+	Index    int
+	Value    string
+	Quoted   bool
+}
+
+// Literals returns the literal values of the create view statement, in its source query.
+func (s CreateViewStatement) Literals() []Literal {
+	if s.View == nil {
+		return nil
+	}
+	return s.View.Literals()
+}
+
+// Literals returns nothing, the describe statement has no literal value.
+func (s DescribeStatement) Literals() []Literal {
+	return nil
+}
+
+// Literals returns the values of the conditions and the dates of the during clause.
+// The placeholders and the named date ranges, as LAST_7_DAYS, are not literals.
+func (s SelectStatement) Literals() (list []Literal) {
+	for i, c := range s.ConditionList() {
+		val, lit := c.Value()
+		for y, v := range val {
+			if lit && isParameter(v) {
+				continue
+			}
+			list = append(list, Literal{Clause: "WHERE", Column: c.Name(), Position: i + 1, Index: y, Value: v, Quoted: !lit})
+		}
+	}
+	for i, d := range s.DuringList() {
+		if isDate(d) {
+			list = append(list, Literal{Clause: "DURING", Position: i + 1, Value: d})
+		}
+	}
+	return
+}
+
+// Literals returns the pattern of the like clause.
+func (s ShowStatement) Literals() []Literal {
+	if p, used := s.LikePattern(); used {
+		return []Literal{{Clause: "LIKE", Position: 1, Value: p.String(), Quoted: true}}
+	}
+	return nil
+}
+
+// Literals returns the client customer ID.
+func (s UseStatement) Literals() []Literal {
+	if s.AccountID() == "" {
+		return nil
+	}
+	return []Literal{{Clause: "USE", Position: 1, Value: s.AccountID()}}
+}
+
+// Redacted outputs the create view statement with the literal values of its source query
+// replaced by a question mark.
+func (s CreateViewStatement) Redacted() string {
+	if s.View != nil {
+		v := s.View.redact()
+		s.View = &v
+	}
+	return s.String()
+}
+
+// Redacted outputs the describe statement.
+func (s DescribeStatement) Redacted() string {
+	return s.String()
+}
+
+// Redacted outputs the select statement with the values of the conditions and the dates
+// replaced by a question mark. A list of values is collapsed to one question mark with its size.
+func (s SelectStatement) Redacted() string {
+	return s.redact().String()
+}
+
+// redact returns a copy of the select statement without its literal values.
+func (s SelectStatement) redact() SelectStatement {
+	if len(s.Where) > 0 {
+		where := make([]Condition, len(s.Where))
+		for i, c := range s.Where {
+			where[i] = c
+			val, lit := c.Value()
+			if lit && onlyParameters(val) {
+				continue
+			}
+			v := placeholder
+			if len(val) > 1 {
+				v += " x" + strconv.Itoa(len(val))
+			}
+			where[i] = &Where{
+				Column:         NewColumn(c.Name(), c.Alias()),
+				Sign:           c.Operator(),
+				ColumnValue:    []string{v},
+				IsValueLiteral: true,
+			}
+		}
+		s.Where = where
+	}
+	if len(s.During) == 2 {
+		during := make([]string, 2)
+		for i, d := range s.During {
+			if during[i] = d; isDate(d) {
+				during[i] = placeholder
+			}
+		}
+		s.During = during
+	}
+	return s
+}
+
+// Redacted outputs the show statement with its pattern replaced by a question mark.
+func (s ShowStatement) Redacted() string {
+	q := s.String()
+	if p, used := s.likeValue(); used {
+		q = strings.Replace(q, " LIKE "+p, " LIKE "+placeholder, 1)
+	}
+	return q
+}
+
+// Redacted outputs the use statement with its client customer ID replaced by a question mark.
+func (s UseStatement) Redacted() string {
+	if s.AccountID() == "" {
+		return ""
+	}
+	return "USE " + placeholder
+}
+
+// isParameter returns true if the value literal is a placeholder, named or not.
+func isParameter(v string) bool {
+	return v == placeholder || isNamedPlaceholder(v)
+}
+
+// onlyParameters returns true if all the value literals are placeholders.
+func onlyParameters(list []string) bool {
+	for _, v := range list {
+		if !isParameter(v) {
+			return false
+		}
+	}
+	return true
+}
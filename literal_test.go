@@ -0,0 +1,66 @@
+package awqlparse_test
+
+import (
+	"reflect"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestStmt_Literals(t *testing.T) {
+	var tests = []struct {
+		q        string
+		literals []awql.Literal
+		redacted string
+	}{
+		{
+			q: `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName = "rv" AND CampaignId IN [1, 2, 3] AND Clicks > ? DURING 20161224,20161225 LIMIT 5`,
+			literals: []awql.Literal{
+				{Clause: "WHERE", Column: "CampaignName", Position: 1, Value: "rv", Quoted: true},
+				{Clause: "WHERE", Column: "CampaignId", Position: 2, Value: "1"},
+				{Clause: "WHERE", Column: "CampaignId", Position: 2, Index: 1, Value: "2"},
+				{Clause: "WHERE", Column: "CampaignId", Position: 2, Index: 2, Value: "3"},
+				{Clause: "DURING", Position: 1, Value: "20161224"},
+				{Clause: "DURING", Position: 2, Value: "20161225"},
+			},
+			redacted: `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName = ? AND CampaignId IN [ ? x3 ] AND Clicks > ? DURING ?,? LIMIT 5`,
+		},
+		{
+			q:        `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId IN [@id] DURING LAST_7_DAYS`,
+			redacted: `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId IN [ @id ] DURING LAST_7_DAYS`,
+		},
+		{
+			q: `CREATE VIEW rv AS SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus IN ["ENABLED"]`,
+			literals: []awql.Literal{
+				{Clause: "WHERE", Column: "CampaignStatus", Position: 1, Value: "ENABLED", Quoted: true},
+			},
+			redacted: `CREATE VIEW rv AS SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus IN ?`,
+		},
+		{
+			q:        `SHOW TABLES LIKE "CAMPAIGN%"\G`,
+			literals: []awql.Literal{{Clause: "LIKE", Position: 1, Value: "CAMPAIGN%", Quoted: true}},
+			redacted: `SHOW TABLES LIKE ?`,
+		},
+		{
+			q:        `USE 123-456-7890`,
+			literals: []awql.Literal{{Clause: "USE", Position: 1, Value: "123-456-7890"}},
+			redacted: `USE ?`,
+		},
+		{
+			q:        `DESC CAMPAIGN_PERFORMANCE_REPORT CampaignName`,
+			redacted: `DESC CAMPAIGN_PERFORMANCE_REPORT CampaignName`,
+		},
+	}
+	for i, tt := range tests {
+		stmt, err := awql.ParseString(tt.q)
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		}
+		if list := stmt[0].Literals(); !reflect.DeepEqual(list, tt.literals) {
+			t.Errorf("%d. Expected %v, received %v", i, tt.literals, list)
+		}
+		if s := stmt[0].Redacted(); s != tt.redacted {
+			t.Errorf("%d. Expected %q, received %q", i, tt.redacted, s)
+		}
+	}
+}
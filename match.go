@@ -0,0 +1,102 @@
+package awqlparse
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Match returns true if the value satisfies the condition.
+// With literal values, numbers are compared as numbers, the other values as strings.
+// It returns an error if the operator can not be evaluated.
+func (c *Where) Match(value string) (bool, error) {
+	val, lit := c.Value()
+	if len(val) == 0 {
+		return false, NewXParserError(ErrMsgSyntax, c.Operator())
+	}
+	switch op := strings.ToUpper(c.Operator()); op {
+	case "=":
+		return compareValues(value, val[0], lit) == 0, nil
+	case "!=":
+		return compareValues(value, val[0], lit) != 0, nil
+	case ">":
+		return compareValues(value, val[0], lit) > 0, nil
+	case ">=":
+		return compareValues(value, val[0], lit) >= 0, nil
+	case "<":
+		return compareValues(value, val[0], lit) < 0, nil
+	case "<=":
+		return compareValues(value, val[0], lit) <= 0, nil
+	case "IN", "NOT_IN":
+		var in bool
+		for _, v := range val {
+			if compareValues(value, v, lit) == 0 {
+				in = true
+				break
+			}
+		}
+		return in == (op == "IN"), nil
+	case "STARTS_WITH":
+		return strings.HasPrefix(value, val[0]), nil
+	case "STARTS_WITH_IGNORE_CASE":
+		return strings.HasPrefix(strings.ToLower(value), strings.ToLower(val[0])), nil
+	case "CONTAINS":
+		return strings.Contains(value, val[0]), nil
+	case "CONTAINS_IGNORE_CASE":
+		return strings.Contains(strings.ToLower(value), strings.ToLower(val[0])), nil
+	case "DOES_NOT_CONTAIN":
+		return !strings.Contains(value, val[0]), nil
+	case "DOES_NOT_CONTAIN_IGNORE_CASE":
+		return !strings.Contains(strings.ToLower(value), strings.ToLower(val[0])), nil
+	}
+	return false, NewXParserError(ErrMsgBadOperator, c.Operator())
+}
+
+// MatchRow returns true if the row, indexed by column names, satisfies all the conditions.
+// It returns an error if a column of the conditions is missing in the row.
+func (s SelectStatement) MatchRow(row map[string]string) (bool, error) {
+	for _, c := range s.ConditionList() {
+		value, ok := row[c.Name()]
+		if !ok {
+			return false, NewXParserError(ErrMsgUnknownColumn, c.Name())
+		}
+		if ok, err := c.Match(value); !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// compareValues returns an integer comparing a and b: 0 if a == b, -1 if a < b, and +1 if a > b.
+// If numeric is true and both are numbers, they are compared as numbers.
+func compareValues(a, b string, numeric bool) int {
+	if numeric {
+		// Boolean literals are compared whatever their case.
+		if isBoolLiteral(a) && isBoolLiteral(b) {
+			return strings.Compare(strings.ToUpper(a), strings.ToUpper(b))
+		}
+		// Integers are compared without conversion to float, to keep large identifiers exact.
+		ia, erra := strconv.ParseInt(a, 10, 64)
+		ib, errb := strconv.ParseInt(b, 10, 64)
+		if erra == nil && errb == nil {
+			switch {
+			case ia < ib:
+				return -1
+			case ia > ib:
+				return 1
+			}
+			return 0
+		}
+		fa, erra := strconv.ParseFloat(a, 64)
+		fb, errb := strconv.ParseFloat(b, 64)
+		if erra == nil && errb == nil {
+			switch {
+			case fa < fb:
+				return -1
+			case fa > fb:
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(a, b)
+}
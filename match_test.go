@@ -0,0 +1,91 @@
+package awqlparse_test
+
+import (
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestWhere_Match(t *testing.T) {
+	var tests = []struct {
+		op     string
+		values []string
+		lit    bool
+		value  string
+		ok     bool
+	}{
+		{op: "=", values: []string{"10"}, lit: true, value: "10.0", ok: true},
+		{op: "=", values: []string{"10"}, value: "10.0"},
+		{op: "=", values: []string{"ENABLED"}, lit: true, value: "ENABLED", ok: true},
+		{op: "!=", values: []string{"ENABLED"}, lit: true, value: "PAUSED", ok: true},
+		{op: "!=", values: []string{"1"}, lit: true, value: "1", ok: false},
+		{op: ">", values: []string{"9"}, lit: true, value: "10", ok: true},
+		{op: ">", values: []string{"9"}, value: "10"},
+		{op: ">=", values: []string{"10"}, lit: true, value: "10", ok: true},
+		{op: "<", values: []string{"1.5"}, lit: true, value: "1.25", ok: true},
+		{op: "<=", values: []string{"b"}, value: "c"},
+		{op: "IN", values: []string{"1", "2"}, lit: true, value: "2", ok: true},
+		{op: "=", values: []string{"9007199254740993"}, lit: true, value: "9007199254740992"},
+		{op: "in", values: []string{"a", "b"}, value: "c"},
+		{op: "=", values: []string{"TRUE"}, lit: true, value: "true", ok: true},
+		{op: "=", values: []string{"TRUE"}, value: "true"},
+		{op: "!=", values: []string{"FALSE"}, lit: true, value: "true", ok: true},
+		{op: "NOT_IN", values: []string{"a", "b"}, value: "c", ok: true},
+		{op: "NOT_IN", values: []string{"a", "b"}, value: "a"},
+		{op: "STARTS_WITH", values: []string{"rv"}, value: "rvflash", ok: true},
+		{op: "STARTS_WITH", values: []string{"RV"}, value: "rvflash"},
+		{op: "STARTS_WITH_IGNORE_CASE", values: []string{"RV"}, value: "rvflash", ok: true},
+		{op: "CONTAINS", values: []string{"fla"}, value: "rvflash", ok: true},
+		{op: "CONTAINS", values: []string{"FLA"}, value: "rvflash"},
+		{op: "CONTAINS_IGNORE_CASE", values: []string{"FLA"}, value: "rvflash", ok: true},
+		{op: "DOES_NOT_CONTAIN", values: []string{"FLA"}, value: "rvflash", ok: true},
+		{op: "DOES_NOT_CONTAIN", values: []string{"fla"}, value: "rvflash"},
+		{op: "DOES_NOT_CONTAIN_IGNORE_CASE", values: []string{"FLA"}, value: "rvflash"},
+	}
+
+	for i, tt := range tests {
+		c := &awql.Where{Column: awql.NewColumn("c", ""), Sign: tt.op, ColumnValue: tt.values, IsValueLiteral: tt.lit}
+		ok, err := c.Match(tt.value)
+		if err != nil {
+			t.Errorf("%d. Expected no error with %v, received %v", i, tt.op, err)
+		}
+		if ok != tt.ok {
+			t.Errorf("%d. Expected %v with %q %v %v, received %v", i, tt.ok, tt.value, tt.op, tt.values, ok)
+		}
+	}
+
+	c := &awql.Where{Column: awql.NewColumn("c", ""), Sign: "LIKE", ColumnValue: []string{"a"}}
+	if _, err := c.Match("a"); err == nil {
+		t.Error("Expected an error with an unsupported operator")
+	}
+}
+
+func TestSelectStmt_MatchRow(t *testing.T) {
+	q := `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 10 AND CampaignStatus IN [ENABLED, PAUSED]`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	stmt := stmts[0].(awql.SelectStmt)
+
+	var tests = []struct {
+		row map[string]string
+		ok  bool
+		err bool
+	}{
+		{row: map[string]string{"Clicks": "11", "CampaignStatus": "ENABLED"}, ok: true},
+		{row: map[string]string{"Clicks": "9", "CampaignStatus": "ENABLED"}},
+		{row: map[string]string{"Clicks": "11", "CampaignStatus": "REMOVED"}},
+		{row: map[string]string{"Clicks": "11"}, err: true},
+	}
+	for i, tt := range tests {
+		ok, err := stmt.MatchRow(tt.row)
+		if (err != nil) != tt.err {
+			t.Errorf("%d. Expected error: %v, received %v", i, tt.err, err)
+		}
+		if ok != tt.ok {
+			t.Errorf("%d. Expected %v with %v, received %v", i, tt.ok, tt.row, ok)
+		}
+	}
+}
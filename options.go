@@ -0,0 +1,245 @@
+package awqlparse
+
+import (
+	"io"
+	"strings"
+)
+
+// options represents the settings of a parser.
+// A limit equals to zero means no limit.
+// They can not be changed once the parser is created.
+type options struct {
+	strict        bool
+	lenientOrder  bool
+	relaxedValues bool
+	dateColumns   map[string]bool
+	defaultDuring string
+	maxInputBytes int64
+	maxIdentBytes int
+	maxStrBytes   int
+	maxFields     int
+	maxConditions int
+	maxStatements int
+	// Observers used to trace the parsing.
+	tokenObserver  func(tk Token, literal string, pos Pos)
+	clauseObserver func(clause string, stmt Stmt)
+}
+
+// Option configures a parser.
+type Option func(*options) error
+
+// StrictAWQL only accepts the SELECT statements supported by Adwords: SELECT...FROM...WHERE...DURING,
+// without asterisk, aggregate function, alias, DISTINCT, EXCEPT, GROUP BY, ORDER BY or LIMIT clauses.
+// Otherwise, as with the other statements, the parse fails with an UnsupportedError naming them.
+// It can not be used with the LenientClauseOrder or RelaxedOperatorValues options.
+func StrictAWQL() Option {
+	return func(o *options) error {
+		o.strict = true
+		return nil
+	}
+}
+
+// LenientClauseOrder accepts the WHERE, DURING, GROUP BY, ORDER BY and LIMIT clauses
+// of a SELECT statement in any order. Each clause can still only be used once.
+func LenientClauseOrder() Option {
+	return func(o *options) error {
+		o.lenientOrder = true
+		return nil
+	}
+}
+
+// RelaxedOperatorValues accepts any kind of value with the operators of the conditions.
+// By default, IN and NOT_IN expect a list and the other operators a single value.
+func RelaxedOperatorValues() Option {
+	return func(o *options) error {
+		o.relaxedValues = true
+		return nil
+	}
+}
+
+// DateColumns adds columns to the Date one whose ISO dates of the conditions,
+// like '2016-12-01', are checked and converted to the AWQL format: 20161201.
+func DateColumns(names ...string) Option {
+	return func(o *options) error {
+		if o.dateColumns == nil {
+			o.dateColumns = make(map[string]bool)
+		}
+		for _, name := range names {
+			if !isIdentifier(name) {
+				return NewXParserError(ErrMsgBadOption, name)
+			}
+			o.dateColumns[name] = true
+		}
+		return nil
+	}
+}
+
+// DefaultDuring sets the date range literal, as LAST_7_DAYS,
+// used by the SELECT statements without DURING clause.
+func DefaultDuring(literal string) Option {
+	return func(o *options) error {
+		if !isDateRangeLiteral(literal) {
+			return NewXParserError(ErrMsgBadOption, literal)
+		}
+		o.defaultDuring = strings.ToUpper(literal)
+		return nil
+	}
+}
+
+// WithTokenObserver calls the function with each token read from the input,
+// except the white spaces, with its position. A token read again is not notified twice.
+func WithTokenObserver(f func(tk Token, literal string, pos Pos)) Option {
+	return func(o *options) error {
+		if f == nil {
+			return NewXParserError(ErrMsgBadOption, "nil token observer")
+		}
+		o.tokenObserver = f
+		return nil
+	}
+}
+
+// WithClauseObserver calls the function each time a clause of a SELECT statement is parsed,
+// as SELECT, FROM or WHERE, with the statement being built.
+func WithClauseObserver(f func(clause string, stmt Stmt)) Option {
+	return func(o *options) error {
+		if f == nil {
+			return NewXParserError(ErrMsgBadOption, "nil clause observer")
+		}
+		o.clauseObserver = f
+		return nil
+	}
+}
+
+// MaxInputBytes limits the size of the input in bytes.
+// The input is read until the limit, the parse fails if there is more to read.
+func MaxInputBytes(n int64) Option {
+	return func(o *options) error {
+		if n <= 0 {
+			return NewXParserError(ErrMsgBadOption, n)
+		}
+		o.maxInputBytes = n
+		return nil
+	}
+}
+
+// MaxIdentifierBytes changes the maximum size in bytes of the identifiers, value literals and numbers.
+// By default, it is DefaultMaxIdentifierBytes. Beyond, the parse fails with a token too long error.
+func MaxIdentifierBytes(n int) Option {
+	return func(o *options) error {
+		if n <= 0 {
+			return NewXParserError(ErrMsgBadOption, n)
+		}
+		o.maxIdentBytes = n
+		return nil
+	}
+}
+
+// MaxStringBytes changes the maximum size in bytes of the strings.
+// By default, it is DefaultMaxStringBytes. Beyond, the parse fails with a token too long error.
+func MaxStringBytes(n int) Option {
+	return func(o *options) error {
+		if n <= 0 {
+			return NewXParserError(ErrMsgBadOption, n)
+		}
+		o.maxStrBytes = n
+		return nil
+	}
+}
+
+// MaxFields limits the number of fields of a SELECT statement.
+func MaxFields(n int) Option {
+	return func(o *options) error {
+		if n <= 0 {
+			return NewXParserError(ErrMsgBadOption, n)
+		}
+		o.maxFields = n
+		return nil
+	}
+}
+
+// MaxConditions limits the number of conditions of the WHERE clause.
+func MaxConditions(n int) Option {
+	return func(o *options) error {
+		if n <= 0 {
+			return NewXParserError(ErrMsgBadOption, n)
+		}
+		o.maxConditions = n
+		return nil
+	}
+}
+
+// MaxStatements limits the number of statements of the input.
+func MaxStatements(n int) Option {
+	return func(o *options) error {
+		if n <= 0 {
+			return NewXParserError(ErrMsgBadOption, n)
+		}
+		o.maxStatements = n
+		return nil
+	}
+}
+
+// NewParserWithOptions returns a new instance of Parser configured with the options.
+func NewParserWithOptions(r io.Reader, opts ...Option) (*Parser, error) {
+	p := &Parser{}
+	for _, opt := range opts {
+		if err := opt(&p.opts); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.opts.check(); err != nil {
+		return nil, err
+	}
+	p.s = NewScanner(p.input(r))
+	p.s.maxIdent, p.s.maxString = p.opts.maxIdentBytes, p.opts.maxStrBytes
+	return p, nil
+}
+
+// check returns an error if the options can not be used together.
+func (o options) check() error {
+	if o.strict && o.lenientOrder {
+		return NewXParserError(ErrMsgBadOption, "StrictAWQL with LenientClauseOrder")
+	}
+	if o.strict && o.relaxedValues {
+		return NewXParserError(ErrMsgBadOption, "StrictAWQL with RelaxedOperatorValues")
+	}
+	return nil
+}
+
+// input returns the reader to scan, limited in size if required.
+func (p *Parser) input(r io.Reader) io.Reader {
+	if p.opts.maxInputBytes == 0 {
+		p.in = nil
+		return r
+	}
+	p.in = &limitedReader{r: r, n: p.opts.maxInputBytes}
+	return p.in
+}
+
+// limitedReader reads at most n bytes from the reader.
+// Beyond, it reports the end of the input and whether there was more to read.
+type limitedReader struct {
+	r        io.Reader
+	n        int64
+	exceeded bool
+}
+
+// Read implements the io.Reader interface.
+func (l *limitedReader) Read(b []byte) (int, error) {
+	if len(b) == 0 || l.exceeded {
+		return 0, io.EOF
+	}
+	if l.n <= 0 {
+		// Reads one more byte to know if the input is larger than the limit.
+		if n, _ := l.r.Read(b[:1]); n > 0 {
+			l.exceeded = true
+		}
+		return 0, io.EOF
+	}
+	if int64(len(b)) > l.n {
+		b = b[:l.n]
+	}
+	n, err := l.r.Read(b)
+	l.n -= int64(n)
+	return n, err
+}
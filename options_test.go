@@ -0,0 +1,482 @@
+package awqlparse_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestLenientClauseOrder(t *testing.T) {
+	var tests = []struct {
+		q, str string
+		err    error
+	}{
+		{
+			q:   `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT DURING LAST_7_DAYS WHERE Clicks > 0`,
+			str: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0 DURING LAST_7_DAYS`,
+		},
+		{
+			q:   `SELECT CampaignId, Cost FROM CAMPAIGN_PERFORMANCE_REPORT LIMIT 5 ORDER BY 2 DESC GROUP BY 1 WHERE Clicks > 0`,
+			str: `SELECT CampaignId, Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0 GROUP BY 1 ORDER BY 2 DESC LIMIT 5`,
+		},
+		{
+			q:   `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0 DURING TODAY WHERE Cost > 0`,
+			err: awql.NewXParserError(awql.ErrMsgDuplicateClause, "WHERE"),
+		},
+		{
+			q:   `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT ORDER BY 1 LIMIT 5 ORDER BY 1 DESC`,
+			err: awql.NewXParserError(awql.ErrMsgDuplicateClause, "ORDER BY"),
+		},
+	}
+	for i, tt := range tests {
+		p, err := awql.NewParserWithOptions(strings.NewReader(tt.q), awql.LenientClauseOrder())
+		if err != nil {
+			t.Fatalf("%d. Expected no error with the options, received %v", i, err)
+		}
+		stmt, err := p.ParseSelect()
+		if tt.err != nil {
+			if err == nil || err.Error() != tt.err.Error() {
+				t.Errorf("%d. Expected the error %v with %q, received %v", i, tt.err, tt.q, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		} else if s := stmt.String(); s != tt.str {
+			t.Errorf("%d. Expected %q, received %q", i, tt.str, s)
+		}
+	}
+}
+
+func TestRelaxedOperatorValues(t *testing.T) {
+	var tests = []struct {
+		q, str string
+	}{
+		{
+			q:   `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = [1, 2]`,
+			str: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = [ 1 , 2 ]`,
+		},
+		{
+			q:   `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus IN 'ENABLED'`,
+			str: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus IN [ 'ENABLED' ]`,
+		},
+	}
+	for i, tt := range tests {
+		if _, err := awql.NewParser(strings.NewReader(tt.q)).ParseSelect(); err == nil {
+			t.Errorf("%d. Expected an error by default with %q", i, tt.q)
+		}
+		p, err := awql.NewParserWithOptions(strings.NewReader(tt.q), awql.RelaxedOperatorValues())
+		if err != nil {
+			t.Fatalf("%d. Expected no error with the options, received %v", i, err)
+		}
+		stmt, err := p.ParseSelect()
+		if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		} else if s := stmt.String(); s != tt.str {
+			t.Errorf("%d. Expected %q, received %q", i, tt.str, s)
+		}
+	}
+}
+
+func TestDateColumns(t *testing.T) {
+	const q = `SELECT Week FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Week = '2016-12-26' AND Date < "2017-01-01"`
+	p, err := awql.NewParserWithOptions(strings.NewReader(q), awql.DateColumns("Week"))
+	if err != nil {
+		t.Fatalf("Expected no error with the options, received %v", err)
+	}
+	stmt, err := p.ParseSelect()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	const str = `SELECT Week FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Week = 20161226 AND Date < 20170101`
+	if s := stmt.String(); s != str {
+		t.Errorf("Expected %q, received %q", str, s)
+	}
+	if _, err := awql.NewParserWithOptions(strings.NewReader(q), awql.DateColumns("Week!")); err == nil {
+		t.Error("Expected an error with an invalid column name")
+	}
+}
+
+// countingReader counts the bytes read from the reader.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(b []byte) (int, error) {
+	n, err := c.r.Read(b)
+	c.n += n
+	return n, err
+}
+
+func TestMaxInputBytes(t *testing.T) {
+	// A single token of 10MB.
+	r := &countingReader{r: strings.NewReader("SELECT " + strings.Repeat("a", 10<<20))}
+	p, err := awql.NewParserWithOptions(r, awql.MaxInputBytes(1024))
+	if err != nil {
+		t.Fatalf("Expected no error with the options, received %v", err)
+	}
+	_, err = p.Parse()
+	if _, ok := err.(*awql.LimitError); !ok {
+		t.Errorf("Expected a limit error, received %v", err)
+	}
+	if r.n > 1025 {
+		t.Errorf("Expected the input to be read until the limit, received %d bytes", r.n)
+	}
+	// The limit is not reached with an input of the same size.
+	q := "SELECT Cost FROM REPORT"
+	p.Reset(strings.NewReader(q))
+	if _, err := p.Parse(); err != nil {
+		t.Errorf("Expected no error with %q, received %v", q, err)
+	}
+	p, _ = awql.NewParserWithOptions(strings.NewReader(q), awql.MaxInputBytes(int64(len(q))))
+	if _, err := p.Parse(); err != nil {
+		t.Errorf("Expected no error with %q at the limit, received %v", q, err)
+	}
+	p, _ = awql.NewParserWithOptions(strings.NewReader(q+"A"), awql.MaxInputBytes(int64(len(q))))
+	if _, err := p.ParseSelect(); err == nil || err.Error() != "ParserError.LIMIT_EXCEEDED (MaxInputBytes: 23)" {
+		t.Errorf("Expected a limit error with a truncated query, received %v", err)
+	}
+}
+
+func TestMaxLimits(t *testing.T) {
+	var tests = []struct {
+		q   string
+		opt awql.Option
+		err string
+	}{
+		{
+			q:   "SELECT Cost, Clicks FROM REPORT",
+			opt: awql.MaxFields(2),
+		},
+		{
+			q:   "SELECT Cost, Clicks, Impressions FROM REPORT",
+			opt: awql.MaxFields(2),
+			err: "ParserError.LIMIT_EXCEEDED (MaxFields: 2)",
+		},
+		{
+			q:   "SELECT Cost FROM REPORT WHERE Cost > 0 AND Clicks > 0",
+			opt: awql.MaxConditions(2),
+		},
+		{
+			q:   "SELECT Cost FROM REPORT WHERE Cost > 0 AND Clicks > 0 AND Impressions > 0",
+			opt: awql.MaxConditions(2),
+			err: "ParserError.LIMIT_EXCEEDED (MaxConditions: 2)",
+		},
+		{
+			q:   "SHOW TABLES; DESC REPORT;",
+			opt: awql.MaxStatements(2),
+		},
+		{
+			q:   "SHOW TABLES; DESC REPORT; USE 123-456-7890",
+			opt: awql.MaxStatements(2),
+			err: "ParserError.LIMIT_EXCEEDED (MaxStatements: 2)",
+		},
+	}
+	for i, tt := range tests {
+		p, err := awql.NewParserWithOptions(strings.NewReader(tt.q), tt.opt)
+		if err != nil {
+			t.Fatalf("%d. Expected no error with the options, received %v", i, err)
+		}
+		_, err = p.Parse()
+		if tt.err == "" {
+			if err != nil {
+				t.Errorf("%d. Expected no error with %q, received %v", i, tt.q, err)
+			}
+		} else if err == nil || err.Error() != tt.err {
+			t.Errorf("%d. Expected the error %v with %q, received %v", i, tt.err, tt.q, err)
+		}
+	}
+	for i, opt := range []awql.Option{awql.MaxInputBytes(0), awql.MaxFields(-1), awql.MaxConditions(0), awql.MaxStatements(0)} {
+		if _, err := awql.NewParserWithOptions(strings.NewReader(""), opt); err == nil {
+			t.Errorf("%d. Expected an error with an invalid limit", i)
+		}
+	}
+}
+
+func TestParser_ParseContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	q := "SELECT Cost FROM REPORT"
+	if _, err := awql.NewParser(strings.NewReader(q)).ParseContext(ctx); err != context.Canceled {
+		t.Errorf("Expected the context error, received %v", err)
+	}
+	if _, err := awql.NewParser(strings.NewReader(q)).ParseContext(context.Background()); err != nil {
+		t.Errorf("Expected no error with %q, received %v", q, err)
+	}
+}
+
+func TestDefaultDuring(t *testing.T) {
+	var tests = []struct {
+		q, str  string
+		implied bool
+	}{
+		{
+			q:       `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT ORDER BY 1`,
+			str:     `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT DURING LAST_7_DAYS ORDER BY 1`,
+			implied: true,
+		},
+		{
+			q:   `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT DURING 20161224,20161225`,
+			str: `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT DURING 20161224,20161225`,
+		},
+	}
+	for i, tt := range tests {
+		p, err := awql.NewParserWithOptions(strings.NewReader(tt.q), awql.DefaultDuring("last_7_days"))
+		if err != nil {
+			t.Fatalf("%d. Expected no error with the options, received %v", i, err)
+		}
+		stmt, err := p.ParseSelect()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		}
+		if s := stmt.String(); s != tt.str {
+			t.Errorf("%d. Expected %q, received %q", i, tt.str, s)
+		}
+		if stmt.DuringImplied() != tt.implied {
+			t.Errorf("%d. Expected the implied date range %v, received %v", i, tt.implied, stmt.DuringImplied())
+		}
+	}
+	if _, err := awql.NewParserWithOptions(strings.NewReader(""), awql.DefaultDuring("20161224")); err == nil {
+		t.Error("Expected an error with a date as default date range")
+	}
+	// An explicit date range replaces the implied one.
+	p, _ := awql.NewParserWithOptions(strings.NewReader(tests[0].q), awql.DefaultDuring("LAST_7_DAYS"))
+	stmt, err := p.ParseSelect()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", tests[0].q, err)
+	}
+	if err := stmt.(*awql.SelectStatement).SetDuring("YESTERDAY"); err != nil {
+		t.Fatalf("Expected no error with a date range literal, received %v", err)
+	}
+	if stmt.DuringImplied() {
+		t.Error("Expected an explicit date range once set")
+	}
+}
+
+func TestStrictAWQL(t *testing.T) {
+	var tests = []struct {
+		q   string
+		err string
+	}{
+		{q: `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0 DURING YESTERDAY`},
+		{
+			q:   `SELECT CampaignName, SUM(Clicks) FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1 LIMIT 5`,
+			err: "ParserError.UNSUPPORTED_CLAUSES (SUM(Clicks), GROUP BY, LIMIT)",
+		},
+		{q: `SELECT * EXCEPT (Cost) FROM CAMPAIGN_PERFORMANCE_REPORT`, err: "ParserError.UNSUPPORTED_CLAUSES (*, EXCEPT)"},
+		{q: `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT LIMIT 10, 5`, err: "ParserError.UNSUPPORTED_CLAUSES (LIMIT)"},
+		{q: `DESC CAMPAIGN_PERFORMANCE_REPORT`, err: "ParserError.UNSUPPORTED_CLAUSES (DESCRIBE)"},
+		{q: `SHOW TABLES`, err: "ParserError.UNSUPPORTED_CLAUSES (SHOW)"},
+		{q: `CREATE VIEW rv AS SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT`, err: "ParserError.UNSUPPORTED_CLAUSES (CREATE VIEW)"},
+		{q: `USE 123-456-7890`, err: "ParserError.UNSUPPORTED_CLAUSES (USE)"},
+	}
+	for i, tt := range tests {
+		p, err := awql.NewParserWithOptions(strings.NewReader(tt.q), awql.StrictAWQL())
+		if err != nil {
+			t.Fatalf("%d. Expected no error with the options, received %v", i, err)
+		}
+		_, err = p.Parse()
+		if tt.err == "" && err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		} else if tt.err != "" && (err == nil || err.Error() != tt.err) {
+			t.Errorf("%d. Expected the error %v with %q, received %v", i, tt.err, tt.q, err)
+		}
+	}
+}
+
+func TestMaxTokenBytes(t *testing.T) {
+	var tests = []struct {
+		q    string
+		opts []awql.Option
+		err  string
+	}{
+		{
+			q:   "SELECT " + strings.Repeat("a", awql.DefaultMaxIdentifierBytes+1) + " FROM R",
+			err: "ParserError.TOKEN_TOO_LONG (" + strings.Repeat("a", 32) + "...)",
+		},
+		{
+			q:   `SELECT Cost FROM R WHERE Name = "` + strings.Repeat("b", awql.DefaultMaxStringBytes+1) + `"`,
+			err: `ParserError.TOKEN_TOO_LONG ("` + strings.Repeat("b", 31) + "...)",
+		},
+		{q: "SELECT " + strings.Repeat("a", 2048) + " FROM R", opts: []awql.Option{awql.MaxIdentifierBytes(4096)}},
+		{
+			q:    `SELECT Cost FROM R WHERE Name = "abcdef"`,
+			opts: []awql.Option{awql.MaxStringBytes(4)},
+			err:  `ParserError.TOKEN_TOO_LONG ("abcd)`,
+		},
+		{q: "SELECT Cost FROM R" + strings.Repeat(" ", awql.DefaultMaxIdentifierBytes+1)},
+	}
+	for i, tt := range tests {
+		p, err := awql.NewParserWithOptions(strings.NewReader(tt.q), tt.opts...)
+		if err != nil {
+			t.Fatalf("%d. Expected no error with the options, received %v", i, err)
+		}
+		_, err = p.ParseSelect()
+		if tt.err == "" && err != nil {
+			t.Errorf("%d. Expected no error, received %v", i, err)
+		} else if tt.err != "" && (err == nil || err.Error() != tt.err) {
+			t.Errorf("%d. Expected the error %q, received %v", i, tt.err, err)
+		}
+	}
+}
+
+func TestNewParserWithOptions(t *testing.T) {
+	var tests = []struct {
+		opts []awql.Option
+		err  error
+	}{
+		{opts: []awql.Option{awql.StrictAWQL(), awql.MaxFields(5)}},
+		{
+			opts: []awql.Option{awql.LenientClauseOrder(), awql.StrictAWQL()},
+			err:  awql.NewXParserError(awql.ErrMsgBadOption, "StrictAWQL with LenientClauseOrder"),
+		},
+		{
+			opts: []awql.Option{awql.StrictAWQL(), awql.RelaxedOperatorValues()},
+			err:  awql.NewXParserError(awql.ErrMsgBadOption, "StrictAWQL with RelaxedOperatorValues"),
+		},
+		{opts: []awql.Option{awql.MaxStatements(0)}, err: awql.NewXParserError(awql.ErrMsgBadOption, 0)},
+		{opts: []awql.Option{awql.MaxIdentifierBytes(-1)}, err: awql.NewXParserError(awql.ErrMsgBadOption, -1)},
+		{opts: []awql.Option{awql.MaxStringBytes(0)}, err: awql.NewXParserError(awql.ErrMsgBadOption, 0)},
+	}
+	for i, tt := range tests {
+		_, err := awql.NewParserWithOptions(strings.NewReader(""), tt.opts...)
+		if tt.err == nil && err != nil {
+			t.Errorf("%d. Expected no error, received %v", i, err)
+		} else if tt.err != nil && (err == nil || err.Error() != tt.err.Error()) {
+			t.Errorf("%d. Expected the error %v, received %v", i, tt.err, err)
+		}
+	}
+}
+
+func ExampleStrictAWQL() {
+	q := `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT ORDER BY 1`
+	p, _ := awql.NewParserWithOptions(strings.NewReader(q), awql.StrictAWQL())
+	_, err := p.ParseSelect()
+	fmt.Println(err)
+	// Output: ParserError.UNSUPPORTED_CLAUSES (ORDER BY)
+}
+
+func ExampleLenientClauseOrder() {
+	q := `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT LIMIT 5 WHERE Clicks > 0`
+	p, _ := awql.NewParserWithOptions(strings.NewReader(q), awql.LenientClauseOrder())
+	stmt, _ := p.ParseSelect()
+	fmt.Println(stmt)
+	// Output: SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0 LIMIT 5
+}
+
+func ExampleRelaxedOperatorValues() {
+	q := `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = [1, 2]`
+	p, _ := awql.NewParserWithOptions(strings.NewReader(q), awql.RelaxedOperatorValues())
+	stmt, _ := p.ParseSelect()
+	fmt.Println(stmt.ConditionList()[0].Value())
+	// Output: [1 2] true
+}
+
+func ExampleDateColumns() {
+	q := `SELECT Week FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Week >= '2016-12-26'`
+	p, _ := awql.NewParserWithOptions(strings.NewReader(q), awql.DateColumns("Week"))
+	stmt, _ := p.ParseSelect()
+	fmt.Println(stmt)
+	// Output: SELECT Week FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Week >= 20161226
+}
+
+func ExampleDefaultDuring() {
+	q := `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT`
+	p, _ := awql.NewParserWithOptions(strings.NewReader(q), awql.DefaultDuring("LAST_7_DAYS"))
+	stmt, _ := p.ParseSelect()
+	fmt.Println(stmt, stmt.DuringImplied())
+	// Output: SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT DURING LAST_7_DAYS true
+}
+
+func ExampleMaxInputBytes() {
+	q := `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT`
+	p, _ := awql.NewParserWithOptions(strings.NewReader(q), awql.MaxInputBytes(16))
+	_, err := p.ParseSelect()
+	fmt.Println(err)
+	// Output: ParserError.LIMIT_EXCEEDED (MaxInputBytes: 16)
+}
+
+func ExampleMaxIdentifierBytes() {
+	q := `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT`
+	p, _ := awql.NewParserWithOptions(strings.NewReader(q), awql.MaxIdentifierBytes(8))
+	_, err := p.ParseSelect()
+	fmt.Println(err)
+	// Output: ParserError.TOKEN_TOO_LONG (Campaign)
+}
+
+func ExampleMaxStringBytes() {
+	q := `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName = "my campaign"`
+	p, _ := awql.NewParserWithOptions(strings.NewReader(q), awql.MaxStringBytes(8))
+	_, err := p.ParseSelect()
+	fmt.Println(err)
+	// Output: ParserError.TOKEN_TOO_LONG ("my campa)
+}
+
+func ExampleMaxFields() {
+	q := `SELECT CampaignId, CampaignName, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT`
+	p, _ := awql.NewParserWithOptions(strings.NewReader(q), awql.MaxFields(2))
+	_, err := p.ParseSelect()
+	fmt.Println(err)
+	// Output: ParserError.LIMIT_EXCEEDED (MaxFields: 2)
+}
+
+func ExampleMaxConditions() {
+	q := `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0 AND Cost > 0`
+	p, _ := awql.NewParserWithOptions(strings.NewReader(q), awql.MaxConditions(1))
+	_, err := p.ParseSelect()
+	fmt.Println(err)
+	// Output: ParserError.LIMIT_EXCEEDED (MaxConditions: 1)
+}
+
+func ExampleMaxStatements() {
+	q := `SHOW TABLES; SHOW FULL TABLES; DESC CAMPAIGN_PERFORMANCE_REPORT`
+	p, _ := awql.NewParserWithOptions(strings.NewReader(q), awql.MaxStatements(2))
+	_, err := p.Parse()
+	fmt.Println(err)
+	// Output: ParserError.LIMIT_EXCEEDED (MaxStatements: 2)
+}
+
+func TestWithTokenObserver(t *testing.T) {
+	const q = "SELECT Cost\nFROM R WHERE Clicks > 0"
+	var trace []string
+	p, err := awql.NewParserWithOptions(strings.NewReader(q),
+		awql.WithTokenObserver(func(tk awql.Token, literal string, pos awql.Pos) {
+			trace = append(trace, fmt.Sprintf("%v %v %q", pos, tk, literal))
+		}),
+		awql.WithClauseObserver(func(clause string, stmt awql.Stmt) {
+			trace = append(trace, clause+": "+stmt.String())
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Expected no error with the options, received %v", err)
+	}
+	if _, err := p.ParseSelect(); err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	exp := []string{
+		`1:1 SELECT "SELECT"`,
+		`1:8 IDENTIFIER "Cost"`,
+		`2:1 FROM "FROM"`,
+		`SELECT: `,
+		`2:6 IDENTIFIER "R"`,
+		`FROM: SELECT Cost FROM R`,
+		`2:8 WHERE "WHERE"`,
+		`2:14 IDENTIFIER "Clicks"`,
+		`2:21 SUPERIOR ">"`,
+		`2:23 DIGIT "0"`,
+		`2:24 EOF ""`,
+		`WHERE: SELECT Cost FROM R WHERE Clicks > 0`,
+	}
+	if !reflect.DeepEqual(trace, exp) {
+		t.Errorf("Expected %q, received %q", exp, trace)
+	}
+	if _, err := awql.NewParserWithOptions(strings.NewReader(q), awql.WithTokenObserver(nil)); err == nil {
+		t.Error("Expected an error with a nil observer")
+	}
+}
@@ -0,0 +1,28 @@
+package awqlparse
+
+// OrderComparator returns a function reporting whether the row a must sort before the row b,
+// following the orders of the ORDER BY clause. The rows are lists of values, as many as width,
+// indexed by the position of their column minus one. The values of the columns whose position is
+// set in numeric are compared as numbers, the others as strings. Rows equal on all the orders
+// are kept in place by a stable sort. It returns an error if a position is out of the rows.
+func OrderComparator(orders []Orderer, width int, numeric map[int]bool) (func(a, b []string) bool, error) {
+	for _, o := range orders {
+		if pos := o.Position(); pos < 1 || pos > width {
+			return nil, NewXParserError(ErrMsgBadOrder, pos)
+		}
+	}
+	return func(a, b []string) bool {
+		for _, o := range orders {
+			pos := o.Position()
+			c := compareValues(a[pos-1], b[pos-1], numeric[pos])
+			if c == 0 {
+				continue
+			}
+			if o.SortDescending() {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	}, nil
+}
@@ -0,0 +1,62 @@
+package awqlparse_test
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+// rows implements the sort.Interface with a comparator.
+type rows struct {
+	data [][]string
+	less func(a, b []string) bool
+}
+
+func (r rows) Len() int           { return len(r.data) }
+func (r rows) Swap(i, j int)      { r.data[i], r.data[j] = r.data[j], r.data[i] }
+func (r rows) Less(i, j int) bool { return r.less(r.data[i], r.data[j]) }
+
+func TestOrderComparator(t *testing.T) {
+	q := `SELECT CampaignName, Clicks, CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT ORDER BY 2 DESC, 1`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	stmt := stmts[0].(awql.SelectStmt)
+
+	data := [][]string{
+		{"b", "9", "1"},
+		{"a", "10", "2"},
+		{"c", "10", "3"},
+		{"a", "9", "4"},
+		{"a", "9", "5"},
+	}
+	less, err := awql.OrderComparator(stmt.OrderList(), 3, map[int]bool{2: true})
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	sort.Stable(rows{data: data, less: less})
+	expected := [][]string{
+		{"a", "10", "2"},
+		{"c", "10", "3"},
+		{"a", "9", "4"},
+		{"a", "9", "5"},
+		{"b", "9", "1"},
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("Expected %v, received %v", expected, data)
+	}
+
+	// Lexical comparison of the clicks.
+	less, _ = awql.OrderComparator(stmt.OrderList(), 3, nil)
+	if less([]string{"a", "10", "1"}, []string{"a", "9", "1"}) {
+		t.Error("Expected 10 after 9 with a lexical descending order")
+	}
+
+	if _, err := awql.OrderComparator(stmt.OrderList(), 1, nil); err == nil {
+		t.Error("Expected an error with a position out of the rows")
+	}
+}
@@ -0,0 +1,42 @@
+package awqlparse
+
+// OutputColumn represents a column of the result of a select statement,
+// as the header of a CSV report.
+type OutputColumn struct {
+	Position int    // position starting at 1, as referenced by GROUP BY and ORDER BY
+	Name     string // display name: the alias, else the aggregate function or the column name
+	Column   Column // source column, with its alias
+	Method   string // aggregate function, if any
+	Distinct bool   // true if the aggregate function only applies on the distinct values
+}
+
+// OutputColumns returns the columns of the result of the select statement, in order.
+// The positions of the GROUP BY and ORDER BY clauses refer to this numbering.
+func (s SelectStatement) OutputColumns() []OutputColumn {
+	cols := make([]OutputColumn, len(s.Fields))
+	for i, f := range s.Fields {
+		alias, _ := f.Alias()
+		c := OutputColumn{
+			Position: i + 1,
+			Name:     alias,
+			Column:   Column{ColumnName: f.Name(), ColumnAlias: alias},
+		}
+		c.Method, c.Distinct, _ = f.Aggregate()
+		if c.Name == "" {
+			c.Name = displayName(c)
+		}
+		cols[i] = c
+	}
+	return cols
+}
+
+// displayName returns the name of the column without alias: SUM(Cost), COUNT(DISTINCT Cost) or Cost.
+func displayName(c OutputColumn) string {
+	if c.Method == "" {
+		return c.Column.ColumnName
+	}
+	if c.Distinct {
+		return c.Method + "(DISTINCT " + c.Column.ColumnName + ")"
+	}
+	return c.Method + "(" + c.Column.ColumnName + ")"
+}
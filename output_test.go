@@ -0,0 +1,37 @@
+package awqlparse_test
+
+import (
+	"reflect"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestSelectStatement_OutputColumns(t *testing.T) {
+	const q = `SELECT CampaignName AS name, SUM(Cost), COUNT(DISTINCT AdGroupId) AS nb, MAX(Clicks), Date ` +
+		`FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 5, 1 ORDER BY nb DESC`
+	stmt, err := awql.ParseSelectString(q)
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	exp := []awql.OutputColumn{
+		{Position: 1, Name: "name", Column: awql.Column{ColumnName: "CampaignName", ColumnAlias: "name"}},
+		{Position: 2, Name: "SUM(Cost)", Column: awql.Column{ColumnName: "Cost"}, Method: "SUM"},
+		{Position: 3, Name: "nb", Column: awql.Column{ColumnName: "AdGroupId", ColumnAlias: "nb"}, Method: "COUNT", Distinct: true},
+		{Position: 4, Name: "MAX(Clicks)", Column: awql.Column{ColumnName: "Clicks"}, Method: "MAX"},
+		{Position: 5, Name: "Date", Column: awql.Column{ColumnName: "Date"}},
+	}
+	cols := stmt.OutputColumns()
+	if !reflect.DeepEqual(cols, exp) {
+		t.Fatalf("Expected %+v, received %+v", exp, cols)
+	}
+	// The positions of the clauses refer to the output columns.
+	for _, g := range stmt.GroupList() {
+		if c := cols[g.Position()-1]; c.Column.ColumnName != g.Name() {
+			t.Errorf("Expected the group on %q at %d, received %q", g.Name(), g.Position(), c.Column.ColumnName)
+		}
+	}
+	if o := stmt.OrderList()[0]; cols[o.Position()-1].Name != "nb" {
+		t.Errorf("Expected the order on the column nb, received %d", o.Position())
+	}
+}
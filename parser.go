@@ -1,72 +1,254 @@
 package awqlparse
 
 import (
+	"context"
 	"io"
 	"strconv"
 	"strings"
+	"time"
 )
 
-// Like with %
-const wildcard = "%"
-
 // Parser represents a parser.
+// A Parser is not safe for concurrent use.
 type Parser struct {
-	s   *Scanner
-	buf struct {
-		t Token  // last read token
-		l string // last read literal
-		n int    // buffer size, char by char, maximum value: 1
+	s     *Scanner
+	opts  options
+	in    *limitedReader
+	ctx   context.Context
+	count int // number of parsed statements
+	buf   struct {
+		tk  [bufSize]Token  // last read tokens, as a ring
+		lit [bufSize]string // last read literals
+		pos [bufSize]Pos    // positions of the last read tokens
+		i   int             // index of the last token read from the scanner
+		n   int             // number of tokens pushed back, to read again
 	}
 }
 
+// bufSize is the number of tokens kept by the parser to look ahead, white spaces included.
+const bufSize = 16
+
+// maxLookahead is the maximum number of non-whitespace tokens the parser looks ahead.
+const maxLookahead = 7
+
+// The buffer must keep the tokens looked ahead, each one preceded by a white space,
+// and the one pushed back before looking ahead: the build fails otherwise.
+var _ [bufSize - 2*maxLookahead - 1]struct{}
+
 // Error messages.
 var (
-	ErrMsgBadStmt         = "unkwown statement"
-	ErrMsgMissingSrc      = "missing source"
-	ErrMsgColumnsNotMatch = "invalid method"
-	ErrMsgBadColumn       = "invalid method"
-	ErrMsgBadMethod       = "invalid method"
-	ErrMsgBadField        = "invalid field"
-	ErrMsgBadFunc         = "invalid function"
-	ErrMsgBadSrc          = "invalid source"
-	ErrMsgBadDuring       = "invalid during"
-	ErrMsgBadGroup        = "invalid group by"
-	ErrMsgBadOrder        = "invalid order by"
-	ErrMsgBadLimit        = "invalid limit"
-	ErrMsgSyntax          = "syntax near"
-	ErrMsgDuringSize      = "unexpected number of date range"
-	ErrMsgDuringLitSize   = "expected date range literal"
-	ErrMsgDuringDateSize  = "expected no literal date"
+	ErrMsgUnknownStmt        = "unknown statement"
+	ErrMsgUnsupportedStmt    = "unsupported statement"
+	ErrMsgReadOnly           = "AWQL is read-only"
+	ErrMsgUnexpectedStmt     = "unexpected statement"
+	ErrMsgMissingSrc         = "missing source"
+	ErrMsgColumnsNotMatch    = "columns not match"
+	ErrMsgMissingColumns     = "missing columns"
+	ErrMsgForwardPosition    = "position not in previous columns"
+	ErrMsgMixedWildcard      = "asterisk mixed with columns"
+	ErrMsgWildcardView       = "asterisk in view"
+	ErrMsgBadColumn          = "unknown column"
+	ErrMsgBadMethod          = "invalid method"
+	ErrMsgBadField           = "invalid field"
+	ErrMsgBadFunc            = "invalid function"
+	ErrMsgBadSrc             = "invalid source"
+	ErrMsgBadAccount         = "invalid account"
+	ErrMsgBadDuring          = "invalid during"
+	ErrMsgBadGroup           = "invalid group by"
+	ErrMsgBadOrder           = "invalid order by"
+	ErrMsgBadLimit           = "invalid limit"
+	ErrMsgBadBool            = "invalid boolean"
+	ErrMsgBadDate            = "invalid date"
+	ErrMsgBadArgCount        = "invalid number of arguments"
+	ErrMsgBadArgType         = "unsupported argument type"
+	ErrMsgMissingParam       = "missing parameter"
+	ErrMsgUnknownParam       = "unknown parameter"
+	ErrMsgSyntax             = "syntax near"
+	ErrMsgDuringSize         = "unexpected number of date range"
+	ErrMsgDuringLitSize      = "expected date range literal"
+	ErrMsgDuringDateSize     = "expected no literal date"
+	ErrMsgBadEncoding        = "invalid encoding version"
+	ErrMsgUnmappable         = "unmappable construct"
+	ErrMsgUnsupported        = "unsupported clauses"
+	ErrMsgBadFormat          = "invalid download format"
+	ErrMsgViewCycle          = "circular view dependency"
+	ErrMsgUnknownTable       = "unknown table"
+	ErrMsgUnknownColumn      = "unknown column"
+	ErrMsgBadOperator        = "unsupported operator"
+	ErrMsgOperatorValue      = "invalid operator value"
+	ErrMsgDuringMissing      = "missing during"
+	ErrMsgDuringOrder        = "start date after end date"
+	ErrMsgClauseOrder        = "invalid clause order"
+	ErrMsgDuplicateClause    = "duplicate clause"
+	ErrMsgDuplicateOrder     = "duplicate order by"
+	ErrMsgRollupNoGroup      = "rollup without group by"
+	ErrMsgUnexpectedFrom     = "unexpected from"
+	ErrMsgValueExpected      = "expected value before"
+	ErrMsgUnterminatedList   = "unterminated list"
+	ErrMsgEmptyList          = "empty list"
+	ErrMsgMissingComma       = "missing comma before"
+	ErrMsgLeadingComma       = "leading comma"
+	ErrMsgUnterminatedString = "unterminated string"
+	ErrMsgBadCursor          = "invalid cursor"
+	ErrMsgBadOption          = "invalid option"
+	ErrMsgLimitExceeded      = "limit exceeded"
+	ErrMsgTokenTooLong       = "token too long"
 )
 
+// ErrMsgBadStmt is the error message of an unknown statement.
+//
+// Deprecated: use ErrMsgUnknownStmt, without the spelling mistake.
+var ErrMsgBadStmt = ErrMsgUnknownStmt
+
 // NewParser returns a new instance of Parser.
 func NewParser(r io.Reader) *Parser {
 	return &Parser{s: NewScanner(r)}
 }
 
+// Reset discards any buffered token and prepares the parser to read from r,
+// reusing its scanner. The options of the parser are kept.
+func (p *Parser) Reset(r io.Reader) {
+	p.s.Init(p.input(r))
+	p.buf.i, p.buf.n = 0, 0
+	p.buf.tk[0], p.buf.lit[0] = 0, ""
+	p.count = 0
+}
+
+// ParseString parses the AWQL statements of the query.
+// It is a shortcut to parse a string without building its reader.
+func ParseString(q string) ([]Stmt, error) {
+	return NewParser(strings.NewReader(q)).Parse()
+}
+
+// ParseRowString parses the query and returns only its first AWQL statement.
+func ParseRowString(q string) (Stmt, error) {
+	return NewParser(strings.NewReader(q)).ParseRow()
+}
+
+// ParseSelectString parses the query as a AWQL SELECT statement.
+func ParseSelectString(q string) (SelectStmt, error) {
+	return NewParser(strings.NewReader(q)).ParseSelect()
+}
+
+// ParseDescribeString parses the query as a AWQL DESCRIBE statement.
+func ParseDescribeString(q string) (DescribeStmt, error) {
+	return NewParser(strings.NewReader(q)).ParseDescribe()
+}
+
+// ParseCreateViewString parses the query as a AWQL CREATE VIEW statement.
+func ParseCreateViewString(q string) (CreateViewStmt, error) {
+	return NewParser(strings.NewReader(q)).ParseCreateView()
+}
+
+// ParseShowString parses the query as a AWQL SHOW statement.
+func ParseShowString(q string) (ShowStmt, error) {
+	return NewParser(strings.NewReader(q)).ParseShow()
+}
+
+// ParseUseString parses the query as a AWQL USE statement.
+func ParseUseString(q string) (UseStmt, error) {
+	return NewParser(strings.NewReader(q)).ParseUse()
+}
+
+// ParseSelects parses all the statements of the input, expected as AWQL SELECT statements.
+func ParseSelects(r io.Reader) ([]SelectStmt, error) {
+	stmts, err := parseKind(r, KindSelect)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]SelectStmt, len(stmts))
+	for i, s := range stmts {
+		list[i] = s.(SelectStmt)
+	}
+	return list, nil
+}
+
+// ParseDescribes parses all the statements of the input, expected as AWQL DESCRIBE statements.
+func ParseDescribes(r io.Reader) ([]DescribeStmt, error) {
+	stmts, err := parseKind(r, KindDescribe)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]DescribeStmt, len(stmts))
+	for i, s := range stmts {
+		list[i] = s.(DescribeStmt)
+	}
+	return list, nil
+}
+
+// ParseCreateViews parses all the statements of the input, expected as AWQL CREATE VIEW statements.
+func ParseCreateViews(r io.Reader) ([]CreateViewStmt, error) {
+	stmts, err := parseKind(r, KindCreateView)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]CreateViewStmt, len(stmts))
+	for i, s := range stmts {
+		list[i] = s.(CreateViewStmt)
+	}
+	return list, nil
+}
+
+// ParseShows parses all the statements of the input, expected as AWQL SHOW statements.
+func ParseShows(r io.Reader) ([]ShowStmt, error) {
+	stmts, err := parseKind(r, KindShow)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]ShowStmt, len(stmts))
+	for i, s := range stmts {
+		list[i] = s.(ShowStmt)
+	}
+	return list, nil
+}
+
+// ParseUses parses all the statements of the input, expected as AWQL USE statements.
+func ParseUses(r io.Reader) ([]UseStmt, error) {
+	stmts, err := parseKind(r, KindUse)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]UseStmt, len(stmts))
+	for i, s := range stmts {
+		list[i] = s.(UseStmt)
+	}
+	return list, nil
+}
+
+// parseKind parses all the statements of the input and checks that they are of the expected kind.
+func parseKind(r io.Reader, kind Kind) ([]Stmt, error) {
+	stmts, err := NewParser(r).Parse()
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range stmts {
+		if k := s.Kind(); k != kind {
+			return nil, NewXParserError(ErrMsgUnexpectedStmt,
+				"statement "+strconv.Itoa(i+1)+" is a "+k.String()+", expected "+kind.String())
+		}
+	}
+	return stmts, nil
+}
+
 // Parse parses a AWQL statement.
-func (p *Parser) Parse() (statements []Stmt, err error) {
+func (p *Parser) Parse() ([]Stmt, error) {
+	return p.ParseContext(context.Background())
+}
+
+// ParseContext parses a AWQL statement.
+// The context is checked between each statement and while reading the list of fields or conditions.
+func (p *Parser) ParseContext(ctx context.Context) (statements []Stmt, err error) {
+	p.ctx = ctx
+	defer func() {
+		p.ctx = nil
+		err = p.exceeded(err)
+	}()
 	for {
-		var stmt Stmt
-		// Retrieve the first token of the statement.
-		tk, _ := p.scanIgnoreWhitespace()
-		switch tk {
-		case DESC, DESCRIBE:
-			p.unscan()
-			stmt, err = p.ParseDescribe()
-		case CREATE:
-			p.unscan()
-			stmt, err = p.ParseCreateView()
-		case SELECT:
-			p.unscan()
-			stmt, err = p.ParseSelect()
-		case SHOW:
-			p.unscan()
-			stmt, err = p.ParseShow()
-		default:
-			err = NewParserError(ErrMsgBadStmt)
+		if err = ctx.Err(); err != nil {
+			return
 		}
-		if err != nil {
+		var stmt Stmt
+		if stmt, err = p.parseStmt(); err != nil {
 			return
 		}
 		statements = append(statements, stmt)
@@ -81,6 +263,92 @@ func (p *Parser) Parse() (statements []Stmt, err error) {
 	return
 }
 
+// ParseNext parses the next AWQL statement and returns it.
+// At the end of the input, the error io.EOF is returned.
+// On a parse error, the rest of the statement is skipped,
+// so the next call parses the following statement.
+func (p *Parser) ParseNext() (Stmt, error) {
+	if tk, _ := p.scanIgnoreWhitespace(); tk == EOF {
+		return nil, p.exceeded(io.EOF)
+	}
+	p.unscan()
+	stmt, err := p.parseStmt()
+	if err = p.exceeded(err); err != nil {
+		p.skipStmt()
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// parseStmt parses the next AWQL statement, whatever its type.
+func (p *Parser) parseStmt() (Stmt, error) {
+	if p.count++; p.opts.maxStatements > 0 && p.count > p.opts.maxStatements {
+		return nil, &LimitError{Limit: "MaxStatements", Max: int64(p.opts.maxStatements)}
+	}
+	// Retrieve the first token of the statement.
+	tk, literal := p.scanIgnoreWhitespace()
+	p.unscan()
+	switch tk {
+	case DESC, DESCRIBE:
+		return p.ParseDescribe()
+	case CREATE:
+		return p.ParseCreateView()
+	case SELECT:
+		return p.ParseSelect()
+	case SHOW:
+		return p.ParseShow()
+	case USE:
+		return p.ParseUse()
+	}
+	p.scan()
+	err := &ParserError{pos: p.pos(), stmt: p.count}
+	if verb := strings.ToUpper(literal); tk == IDENTIFIER && writeVerbs[verb] {
+		// A SQL statement modifying data.
+		if next, table := p.peek(1); verb == "ALTER" && next == IDENTIFIER && strings.EqualFold(table, "TABLE") {
+			verb += " TABLE"
+		}
+		err.s, err.a, err.r = formatError(ErrMsgUnsupportedStmt), verb, ErrMsgReadOnly
+		return nil, err
+	}
+	err.s, err.h = formatError(ErrMsgUnknownStmt), suggest(literal, statementNames)
+	if literal != "" {
+		err.a = literal
+	}
+	return nil, err
+}
+
+// clauseDone notifies the observer of the clauses, if any, that the clause of the statement is parsed.
+func (p *Parser) clauseDone(clause string, stmt Stmt) {
+	if p.opts.clauseObserver != nil {
+		p.opts.clauseObserver(clause, stmt)
+	}
+}
+
+// unsupported returns an error in strict mode if the kind of statement is not supported by Adwords.
+func (p *Parser) unsupported(k Kind) error {
+	if p.opts.strict && k != KindSelect {
+		return &UnsupportedError{Clauses: []string{k.String()}}
+	}
+	return nil
+}
+
+// skipStmt consumes all the tokens until the end of the current statement.
+func (p *Parser) skipStmt() {
+	if tk, _ := p.lastRead(); p.buf.n == 0 && isTerminator(tk) {
+		// The statement ending has already been read.
+		return
+	}
+	for {
+		switch tk, _ := p.scan(); {
+		case tk == EOF:
+			p.unscan()
+			return
+		case isTerminator(tk):
+			return
+		}
+	}
+}
+
 // ParseRow parses a AWQL statement and returns only the first.
 func (p *Parser) ParseRow() (Stmt, error) {
 	stmts, err := p.Parse()
@@ -91,59 +359,85 @@ func (p *Parser) ParseRow() (Stmt, error) {
 }
 
 // ParseDescribe parses a AWQL DESCRIBE statement.
-func (p *Parser) ParseDescribe() (DescribeStmt, error) {
+func (p *Parser) ParseDescribe() (_ DescribeStmt, err error) {
+	defer func() { err = p.exceeded(p.incomplete(err)) }()
+
 	// First token should be a "DESC" keyword.
 	if tk, literal := p.scanIgnoreWhitespace(); tk != DESC && tk != DESCRIBE {
 		return nil, NewXParserError(ErrMsgBadMethod, literal)
 	}
+	if err := p.unsupported(KindDescribe); err != nil {
+		return nil, err
+	}
 	stmt := &DescribeStatement{}
 
 	// Next we may see the "FULL" keyword.
-	if tk, _ := p.scanIgnoreWhitespace(); tk == FULL {
-		stmt.Full = true
-	} else {
-		p.unscan()
-	}
+	stmt.Full = p.accept(FULL)
 
-	// Next we should read the table name.
-	if tk, literal := p.scanIgnoreWhitespace(); tk == IDENTIFIER {
+	// Next we should read the table name, optionally followed by a dot and the column name.
+	tk, literal := p.scanIgnoreWhitespace()
+	switch tk {
+	case IDENTIFIER:
 		stmt.TableName = literal
-	} else {
+	case VALUE_LITERAL:
+		i := strings.IndexByte(literal, '.')
+		if !isIdentifier(literal[:i]) || !isIdentifier(literal[i+1:]) {
+			return nil, NewXParserError(ErrMsgBadSrc, literal)
+		}
+		stmt.TableName = literal[:i]
+		stmt.Fields = append(stmt.Fields, NewDynamicColumn(NewColumn(literal[i+1:], ""), "", false))
+	case FROM:
+		// As with SHOW FIELDS FROM, the table may be preceded by FROM.
+		if next, table := p.peek(1); next == IDENTIFIER {
+			hint := "DESC "
+			if stmt.Full {
+				hint += "FULL "
+			}
+			return nil, newHintParserError(ErrMsgUnexpectedFrom, literal, hint+table)
+		}
+		return nil, NewXParserError(ErrMsgUnexpectedFrom, literal)
+	default:
 		return nil, NewXParserError(ErrMsgBadSrc, literal)
 	}
 
-	// Next we may see a column name.
-	if tk, literal := p.scanIgnoreWhitespace(); tk == IDENTIFIER {
-		field := NewDynamicColumn(NewColumn(literal, ""), "", false)
-		stmt.Fields = append(stmt.Fields, field)
-	} else {
-		p.unscan()
+	// Next we may see a column name, if it is not already given with the table.
+	if len(stmt.Fields) == 0 {
+		if tk, literal := p.scanIgnoreWhitespace(); tk == IDENTIFIER {
+			field := NewDynamicColumn(NewColumn(literal, ""), "", false)
+			stmt.Fields = append(stmt.Fields, field)
+		} else {
+			p.unscan()
+		}
 	}
 
 	// Finally, we should find the end of the query.
-	var err error
-	if stmt.GModifier, err = p.scanQueryEnding(); err != nil {
+	end, err := p.scanQueryEnding()
+	if err != nil {
 		return nil, err
 	}
+	stmt.terminate(end)
 	return stmt, nil
 }
 
 // ParseCreateView parses a AWQL CREATE VIEW statement.
-func (p *Parser) ParseCreateView() (CreateViewStmt, error) {
+func (p *Parser) ParseCreateView() (_ CreateViewStmt, err error) {
+	defer func() { err = p.exceeded(p.incomplete(err)) }()
+
 	// First token should be a "CREATE" keyword.
 	if tk, literal := p.scanIgnoreWhitespace(); tk != CREATE {
 		return nil, NewXParserError(ErrMsgBadMethod, literal)
 	}
+	if err := p.unsupported(KindCreateView); err != nil {
+		return nil, err
+	}
 	stmt := &CreateViewStatement{}
 
 	// Next we may see the "OR" keyword.
-	if tk, _ := p.scanIgnoreWhitespace(); tk == OR {
+	if p.accept(OR) {
 		if tk, literal := p.scanIgnoreWhitespace(); tk != REPLACE {
 			return nil, NewXParserError(ErrMsgSyntax, literal)
 		}
 		stmt.Replace = true
-	} else {
-		p.unscan()
 	}
 
 	// Next we should see the "VIEW" keyword.
@@ -188,6 +482,11 @@ func (p *Parser) ParseCreateView() (CreateViewStmt, error) {
 	}
 	stmt.View = selectStmt.(*SelectStatement)
 
+	// The columns of the view must be known.
+	if stmt.View.hasWildcard() {
+		return nil, NewXParserError(ErrMsgWildcardView, "*")
+	}
+
 	// Checks if the nomber of view's columns match with the source.
 	if vcs := len(stmt.Fields); vcs > 0 {
 		if vcs != len(stmt.View.Fields) {
@@ -198,19 +497,20 @@ func (p *Parser) ParseCreateView() (CreateViewStmt, error) {
 }
 
 // ParseShow parses a AWQL SHOW statement.
-func (p *Parser) ParseShow() (ShowStmt, error) {
+func (p *Parser) ParseShow() (_ ShowStmt, err error) {
+	defer func() { err = p.exceeded(p.incomplete(err)) }()
+
 	// First token should be a "SHOW" keyword.
 	if tk, literal := p.scanIgnoreWhitespace(); tk != SHOW {
 		return nil, NewXParserError(ErrMsgBadMethod, literal)
 	}
+	if err := p.unsupported(KindShow); err != nil {
+		return nil, err
+	}
 	stmt := &ShowStatement{}
 
 	// Next we may see the "FULL" keyword.
-	if tk, _ := p.scanIgnoreWhitespace(); tk == FULL {
-		stmt.Full = true
-	} else {
-		p.unscan()
-	}
+	stmt.Full = p.accept(FULL)
 
 	// Next we should see the "TABLES" keyword.
 	if tk, literal := p.scanIgnoreWhitespace(); tk != TABLES {
@@ -218,7 +518,8 @@ func (p *Parser) ParseShow() (ShowStmt, error) {
 	}
 
 	// Next we may find a LIKE or WITH keyword.
-	if clause, _ := p.scanIgnoreWhitespace(); clause == LIKE || clause == WITH {
+	if clause, _ := p.peek(1); clause == LIKE || clause == WITH {
+		p.scanIgnoreWhitespace()
 		// And then, the search pattern.
 		tk, pattern := p.scanIgnoreWhitespace()
 		switch tk {
@@ -231,46 +532,85 @@ func (p *Parser) ParseShow() (ShowStmt, error) {
 		case STRING:
 			if clause == LIKE {
 				// Like clause can have a wildcard characters in the pattern.
-				wl := strings.HasPrefix(pattern, wildcard)
-				wr := strings.HasSuffix(pattern, wildcard)
-				like := Pattern{}
-				if wl == wr && wl {
-					like.Contains = strings.Trim(pattern, wildcard)
-				} else if wl == wr && !wl {
-					like.Equal = pattern
-				} else if wl {
-					like.Suffix = strings.TrimPrefix(pattern, wildcard)
-				} else if wr {
-					like.Prefix = strings.TrimSuffix(pattern, wildcard)
-				}
-				stmt.Like = like
+				stmt.Like = newPattern(pattern)
 			} else {
 				stmt.With = pattern
 				stmt.UseWith = true
 			}
+		case UNTERMINATED_STRING:
+			return nil, NewXParserError(ErrMsgUnterminatedString, pattern)
 		default:
 			return nil, NewXParserError(ErrMsgSyntax, pattern)
 		}
-	} else {
-		p.unscan()
 	}
 
 	// Finally, we should find the end of the query.
-	var err error
-	if stmt.GModifier, err = p.scanQueryEnding(); err != nil {
+	end, err := p.scanQueryEnding()
+	if err != nil {
+		return nil, err
+	}
+	stmt.terminate(end)
+	return stmt, nil
+}
+
+// ParseUse parses a AWQL USE statement.
+func (p *Parser) ParseUse() (_ UseStmt, err error) {
+	defer func() { err = p.exceeded(p.incomplete(err)) }()
+
+	// First token should be a "USE" keyword.
+	if tk, literal := p.scanIgnoreWhitespace(); tk != USE {
+		return nil, NewXParserError(ErrMsgBadMethod, literal)
+	}
+	if err := p.unsupported(KindUse); err != nil {
+		return nil, err
+	}
+	stmt := &UseStatement{}
+
+	// Next we should read the client customer ID, as string or dash-separated digits.
+	tk, literal := p.scanIgnoreWhitespace()
+	switch tk {
+	case STRING:
+		stmt.Account = literal
+	case DIGIT:
+		stmt.Account = literal
+		for {
+			// The scanner splits 123-456-7890 into digits and dashes, so we join them.
+			if tk, literal := p.scan(); tk != ILLEGAL || literal != "-" {
+				p.unscan()
+				break
+			}
+			tk, literal := p.scan()
+			if tk != DIGIT {
+				return nil, NewXParserError(ErrMsgBadAccount, stmt.Account+"-"+literal)
+			}
+			stmt.Account += "-" + literal
+		}
+	default:
+		return nil, NewXParserError(ErrMsgBadAccount, literal)
+	}
+
+	// Finally, we should find the end of the query.
+	end, err := p.scanQueryEnding()
+	if err != nil {
 		return nil, err
 	}
+	stmt.terminate(end)
 	return stmt, nil
 }
 
 // ParseSelect parses a AWQL SELECT statement.
-func (p *Parser) ParseSelect() (SelectStmt, error) {
+func (p *Parser) ParseSelect() (_ SelectStmt, err error) {
+	defer func() { err = p.exceeded(p.incomplete(err)) }()
+
 	// First token should be a "SELECT" keyword.
 	if tk, literal := p.scanIgnoreWhitespace(); tk != SELECT {
 		return nil, NewXParserError(ErrMsgBadMethod, literal)
 	}
 	stmt := &SelectStatement{}
 
+	// Next we may see the "DISTINCT" keyword, applied to the whole row.
+	stmt.Unique = p.accept(DISTINCT)
+
 	// Next we should loop over all our comma-delimited fields.
 	for {
 		// Read a field.
@@ -278,20 +618,26 @@ func (p *Parser) ParseSelect() (SelectStmt, error) {
 		tk, literal := p.scanIgnoreWhitespace()
 		switch tk {
 		case ASTERISK:
+			// The rune '*' selects all the columns, it can not be mixed with others.
+			if len(stmt.Fields) > 0 {
+				return nil, NewXParserError(ErrMsgMixedWildcard, literal)
+			}
 			field.ColumnName = literal
-		case DISTINCT:
-			if err := p.scanDistinct(field); err != nil {
-				return nil, err
+
+			// Next we may read columns to exclude.
+			if p.accept(EXCEPT) {
+				if err := p.parseExcept(stmt); err != nil {
+					return nil, err
+				}
 			}
 		case IDENTIFIER:
 			// Next we may find a function declaration.
-			if tk, _ := p.scan(); tk != LEFT_PARENTHESIS {
+			if !p.accept(LEFT_PARENTHESIS) {
 				// Just a column name.
 				field.ColumnName = literal
-				p.unscan()
 			} else if !isFunction(literal) {
 				// This function does not exist.
-				return nil, NewXParserError(ErrMsgBadFunc, literal)
+				return nil, newHintParserError(ErrMsgBadFunc, literal, suggest(literal, functionNames))
 			} else {
 				// It is an aggregate function.
 				field.Method = strings.ToUpper(literal)
@@ -310,7 +656,14 @@ func (p *Parser) ParseSelect() (SelectStmt, error) {
 						return nil, err
 					}
 				case DIGIT:
-					digit, _ := strconv.Atoi(literal)
+					digit, err := parseInt(literal)
+					if err != nil {
+						return nil, NewXParserError(ErrMsgSyntax, literal)
+					}
+					if digit > len(stmt.Fields) {
+						// Only the previous columns can be referenced by their position.
+						return nil, NewXParserError(ErrMsgForwardPosition, literal)
+					}
 					column, err := stmt.searchColumnByPosition(digit)
 					if err != nil {
 						return nil, NewXParserError(ErrMsgSyntax, literal)
@@ -332,31 +685,50 @@ func (p *Parser) ParseSelect() (SelectStmt, error) {
 		}
 
 		// Next we may find an alias name for the column.
-		if tk, _ := p.scanIgnoreWhitespace(); tk == AS {
+		switch tk, alias := p.peek(1); tk {
+		case AS:
+			if next, _ := p.peek(2); next == COMMA || next == FROM {
+				// An alias named AS, without keyword.
+				p.scanIgnoreWhitespace()
+				field.ColumnAlias = alias
+				break
+			}
 			// By using the "AS" keyword.
+			p.scanIgnoreWhitespace()
 			tk, literal := p.scanIgnoreWhitespace()
 			if tk != IDENTIFIER {
 				return nil, NewXParserError(ErrMsgBadField, literal)
 			}
 			field.ColumnAlias = literal
-		} else if tk == IDENTIFIER {
+		case IDENTIFIER:
 			// Or without keyword.
-			field.ColumnAlias = literal
-		} else {
-			p.unscan()
+			p.scanIgnoreWhitespace()
+			field.ColumnAlias = alias
 		}
 		// Finally, add this field with the others.
 		stmt.Fields = append(stmt.Fields, field)
+		if max := p.opts.maxFields; max > 0 && len(stmt.Fields) > max {
+			return nil, &LimitError{Limit: "MaxFields", Max: int64(max)}
+		}
+		if err := p.canceled(); err != nil {
+			return nil, err
+		}
 
 		// If the next token is not a comma then break the loop.
-		if tk, _ := p.scanIgnoreWhitespace(); tk != COMMA {
-			p.unscan()
+		if !p.accept(COMMA) {
 			break
 		}
+		if stmt.hasWildcard() {
+			return nil, NewXParserError(ErrMsgMixedWildcard, "*")
+		}
 	}
+	p.clauseDone("SELECT", stmt)
 
 	// Next we should see the "FROM" keyword.
-	if tk, _ := p.scanIgnoreWhitespace(); tk != FROM {
+	if tk, literal := p.scanIgnoreWhitespace(); tk == EXCEPT {
+		// Columns can only be excluded from the rune '*'.
+		return nil, NewXParserError(ErrMsgSyntax, literal)
+	} else if tk != FROM {
 		return nil, NewParserError(ErrMsgMissingSrc)
 	}
 
@@ -366,209 +738,482 @@ func (p *Parser) ParseSelect() (SelectStmt, error) {
 		return nil, NewXParserError(ErrMsgBadSrc, literal)
 	}
 	stmt.TableName = literal
+	p.clauseDone("FROM", stmt)
 
-	// Newt we may read a "WHERE" keyword.
-	if tk, _ := p.scanIgnoreWhitespace(); tk == WHERE {
-		for {
-			// Parse each condition, begin by the column name.
-			cond := &Where{Column: &Column{}}
-			tk, literal := p.scanIgnoreWhitespace()
-			if tk != IDENTIFIER {
-				return nil, NewXParserError(ErrMsgBadField, literal)
+	// Next we may read the optional clauses: WHERE, DURING, GROUP BY, ORDER BY and LIMIT.
+	// By default, they must follow this order. In lenient mode, they can come in any order.
+	// In both cases, each clause can only be used once.
+	var last Token
+	seen := make(map[Token]bool)
+	for {
+		tk, _ := p.scanIgnoreWhitespace()
+		if _, ok := clauseRanks[tk]; !ok {
+			p.unscan()
+			if next, literal := p.peek(2); tk == WITH && isRollup(next, literal) {
+				// The modifier must directly follow the columns of the group by clause.
+				if !seen[GROUP] {
+					return nil, NewXParserError(ErrMsgRollupNoGroup, "WITH ROLLUP")
+				}
+				return nil, NewXParserError(ErrMsgClauseOrder, "WITH ROLLUP after "+clauseNames[last])
 			}
-			cond.ColumnName = literal
+			break
+		}
+		if seen[tk] {
+			return nil, NewXParserError(ErrMsgDuplicateClause, clauseNames[tk])
+		}
+		if !p.opts.lenientOrder && clauseRanks[tk] < clauseRanks[last] {
+			return nil, NewXParserError(ErrMsgClauseOrder, clauseNames[tk]+" after "+clauseNames[last])
+		}
+		seen[tk], last = true, tk
 
-			// Expects the operator.
-			tk, literal = p.scanIgnoreWhitespace()
-			if !isOperator(tk) {
-				return nil, NewXParserError(ErrMsgSyntax, literal)
-			}
-			cond.Sign = literal
+		var err error
+		switch tk {
+		case WHERE:
+			err = p.parseWhere(stmt)
+		case DURING:
+			err = p.parseDuring(stmt)
+		case GROUP:
+			err = p.parseGroupBy(stmt)
+		case ORDER:
+			err = p.parseOrderBy(stmt)
+		case LIMIT:
+			err = p.parseLimit(stmt)
+		}
+		if err != nil {
+			return nil, err
+		}
+		p.clauseDone(clauseNames[tk], stmt)
+	}
+
+	if !seen[DURING] && p.opts.defaultDuring != "" {
+		stmt.During, stmt.ImpliedDuring = []string{p.opts.defaultDuring}, true
+	}
 
-			// And the value of the condition.ValueLiteral | String | ValueLiteralList | StringList
-			tk, literal = p.scanIgnoreWhitespace()
-			switch tk {
-			case DECIMAL, DIGIT, VALUE_LITERAL:
+	// Finally, we should find the end of the query.
+	end, err := p.scanQueryEnding()
+	if err != nil {
+		return nil, err
+	}
+	stmt.terminate(end)
+	if p.opts.strict {
+		if clauses := stmt.unsupportedClauses(); len(clauses) > 0 {
+			return nil, &UnsupportedError{Clauses: clauses}
+		}
+	}
+	return stmt, nil
+}
+
+// clauseRanks lists the optional clauses of a SELECT statement, in the order expected by AWQL.
+var clauseRanks = map[Token]int{
+	WHERE:  1,
+	DURING: 2,
+	GROUP:  3,
+	ORDER:  4,
+	LIMIT:  5,
+}
+
+// clauseNames lists the names of the optional clauses of a SELECT statement.
+var clauseNames = map[Token]string{
+	WHERE:  "WHERE",
+	DURING: "DURING",
+	GROUP:  "GROUP BY",
+	ORDER:  "ORDER BY",
+	LIMIT:  "LIMIT",
+}
+
+// parseExcept parses the list of columns to exclude, between parentheses.
+func (p *Parser) parseExcept(stmt *SelectStatement) error {
+	if tk, literal := p.scanIgnoreWhitespace(); tk != LEFT_PARENTHESIS {
+		return NewXParserError(ErrMsgSyntax, literal)
+	}
+	for {
+		tk, literal := p.scanIgnoreWhitespace()
+		if tk != IDENTIFIER {
+			return NewXParserError(ErrMsgSyntax, literal)
+		}
+		stmt.Excluded = append(stmt.Excluded, NewColumn(literal, ""))
+
+		// If the next token is not a comma then we expect the end of the list.
+		if tk, literal = p.scanIgnoreWhitespace(); tk == RIGHT_PARENTHESIS {
+			return nil
+		} else if tk != COMMA {
+			return NewXParserError(ErrMsgSyntax, literal)
+		}
+	}
+}
+
+// parseWhere parses the conditions of the WHERE clause.
+func (p *Parser) parseWhere(stmt *SelectStatement) error {
+	for {
+		// Parse each condition, begin by the column name.
+		cond := &Where{Column: &Column{}}
+		tk, literal := p.scanIgnoreWhitespace()
+		if tk != IDENTIFIER {
+			return NewXParserError(ErrMsgBadField, literal)
+		}
+		cond.ColumnName = literal
+
+		// Expects the operator.
+		op, literal := p.scanIgnoreWhitespace()
+		if !isOperator(op) {
+			return NewXParserError(ErrMsgSyntax, literal)
+		}
+		// Stores the canonical form of the operator.
+		cond.Sign = operators[op]
+
+		// And the value of the condition.ValueLiteral | String | ValueLiteralList | StringList
+		tk, literal = p.scanIgnoreWhitespace()
+		list := tk == LEFT_SQUARE_BRACKETS
+		switch {
+		case tk == DECIMAL, tk == DIGIT, tk == VALUE_LITERAL, tk == PLACEHOLDER, tk == NAMED_PLACEHOLDER,
+			tk == IDENTIFIER && isBoolLiteral(literal):
+			cond.IsValueLiteral = true
+			cond.ColumnValue = append(cond.ColumnValue, normalizeValue(literal))
+		case tk == STRING:
+			cond.ColumnValue = append(cond.ColumnValue, literal)
+		case list:
+			p.unscan()
+			var err error
+			tk, cond.ColumnValue, err = p.scanValueList()
+			switch {
+			case err != nil:
+				return err
+			case tk == VALUE_LITERAL_LIST:
 				cond.IsValueLiteral = true
-				fallthrough
-			case STRING:
-				cond.ColumnValue = append(cond.ColumnValue, literal)
-			case LEFT_SQUARE_BRACKETS:
-				p.unscan()
-				if tk, cond.ColumnValue = p.scanValueList(); tk != VALUE_LITERAL_LIST && tk != STRING_LIST {
-					return nil, NewXParserError(ErrMsgSyntax, literal)
-				} else if tk == VALUE_LITERAL_LIST {
-					cond.IsValueLiteral = true
+				for i, v := range cond.ColumnValue {
+					cond.ColumnValue[i] = normalizeValue(v)
 				}
+			case tk == STRING_LIST:
+			case tk == RIGHT_SQUARE_BRACKETS:
+				return NewXParserError(ErrMsgEmptyList, "[]")
+			case tk == EOF:
+				return NewXParserError(ErrMsgUnterminatedList, literal+strings.Join(cond.ColumnValue, ","))
+			case tk == UNTERMINATED_STRING:
+				_, literal := p.lastRead()
+				return NewXParserError(ErrMsgUnterminatedString, literal)
 			default:
-				return nil, NewXParserError(ErrMsgSyntax, literal)
+				return NewXParserError(ErrMsgSyntax, literal)
 			}
-			stmt.Where = append(stmt.Where, cond)
-
-			// If the next token is not an "AND" keyword then break the loop.
-			if tk, _ := p.scanIgnoreWhitespace(); tk != AND {
-				p.unscan()
-				break
+		default:
+			return p.valueError(tk, literal)
+		}
+		if err := p.normalizeDates(cond); err != nil {
+			return err
+		}
+		// Checks that the operator can be applied on this kind of value.
+		if !p.opts.relaxedValues && isListOperator(op) != list {
+			if isListOperator(op) {
+				return NewXParserError(ErrMsgOperatorValue, cond.Sign+" expects a list")
 			}
+			return NewXParserError(ErrMsgOperatorValue, cond.Sign+" expects a single value")
+		}
+		stmt.Where = append(stmt.Where, cond)
+		if max := p.opts.maxConditions; max > 0 && len(stmt.Where) > max {
+			return &LimitError{Limit: "MaxConditions", Max: int64(max)}
+		}
+		if err := p.canceled(); err != nil {
+			return err
+		}
+
+		// If the next token is not an "AND" keyword then break the loop.
+		if !p.accept(AND) {
+			return nil
 		}
-	} else {
-		// No where clause.
-		p.unscan()
 	}
+}
 
-	// Next we may read a "DURING" keyword.
-	if tk, _ := p.scanIgnoreWhitespace(); tk == DURING {
-		var dateLiteral bool
-		for {
-			// Read the field used to group.
-			tk, literal := p.scanIgnoreWhitespace()
-			if tk == DIGIT && isDate(literal) {
-				stmt.During = append(stmt.During, literal)
-			} else if tk == IDENTIFIER && isDateRangeLiteral(literal) {
-				stmt.During = append(stmt.During, literal)
-				dateLiteral = true
-			} else {
-				return nil, NewXParserError(ErrMsgBadDuring, literal)
-			}
-			// If the next token is not a comma then break the loop.
-			if tk, _ := p.scanIgnoreWhitespace(); tk != COMMA {
-				p.unscan()
-				break
-			}
+// normalizeDates converts the ISO dates of a condition on a date column
+// to the YYYYMMDD form expected by AWQL. The condition then uses value literals.
+func (p *Parser) normalizeDates(cond *Where) error {
+	if cond.IsValueLiteral || !p.isDateColumn(cond.ColumnName) {
+		return nil
+	}
+	return normalizeISODates(cond)
+}
+
+// normalizeISODates converts the values of the condition to the YYYYMMDD form
+// when all of them are ISO dates. The condition then uses value literals.
+func normalizeISODates(cond *Where) error {
+	dates := make([]string, len(cond.ColumnValue))
+	for i, v := range cond.ColumnValue {
+		if !isISODate(v) {
+			return nil
 		}
-		// Checks expected bounds.
-		if rangeSize := len(stmt.During); rangeSize > 2 {
-			return nil, NewXParserError(ErrMsgBadDuring, ErrMsgDuringSize)
-		} else if rangeSize == 1 && !dateLiteral {
-			return nil, NewXParserError(ErrMsgBadDuring, ErrMsgDuringLitSize)
-		} else if rangeSize == 2 && dateLiteral {
-			return nil, NewXParserError(ErrMsgBadDuring, ErrMsgDuringDateSize)
+		d, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return NewXParserError(ErrMsgBadDate, v)
 		}
-	} else {
-		// No during clause.
-		p.unscan()
+		dates[i] = d.Format("20060102")
 	}
+	cond.ColumnValue, cond.IsValueLiteral = dates, true
+	return nil
+}
 
-	// Next we may see a "GROUP" keyword.
-	if tk, _ := p.scanIgnoreWhitespace(); tk == GROUP {
-		if tk, literal := p.scanIgnoreWhitespace(); tk != BY {
-			return nil, NewXParserError(ErrMsgBadGroup, literal)
+// isDateColumn returns true if the column contains dates.
+func (p *Parser) isDateColumn(name string) bool {
+	return name == "Date" || p.opts.dateColumns[name]
+}
+
+// valueError returns the error to use when the token is not the value of a condition.
+// It names the clause or the string literal whose ending is missing.
+func (p *Parser) valueError(tk Token, literal string) error {
+	if _, ok := clauseRanks[tk]; ok || tk == FROM {
+		return NewXParserError(ErrMsgValueExpected, strings.ToUpper(literal))
+	}
+	if tk == UNTERMINATED_STRING {
+		return NewXParserError(ErrMsgUnterminatedString, literal)
+	}
+	return NewXParserError(ErrMsgSyntax, literal)
+}
+
+// parseDuring parses the date range of the DURING clause.
+func (p *Parser) parseDuring(stmt *SelectStatement) error {
+	for {
+		// Read the field used to group.
+		tk, literal := p.scanIgnoreWhitespace()
+		if tk == DIGIT && isDate(literal) {
+			stmt.During = append(stmt.During, literal)
+		} else if tk == IDENTIFIER && isDateRangeLiteral(literal) {
+			// Stores the canonical form of the keyword.
+			stmt.During = append(stmt.During, strings.ToUpper(literal))
+		} else if tk == NAMED_PLACEHOLDER {
+			// The date range is checked when the parameter is bound.
+			stmt.During = append(stmt.During, literal)
+		} else if tk == IDENTIFIER {
+			return newHintParserError(ErrMsgBadDuring, literal, suggest(literal, DateRangeLiterals()))
+		} else {
+			return NewXParserError(ErrMsgBadDuring, literal)
 		}
-		for {
-			// Read the field used to group.
-			tk, literal := p.scanIgnoreWhitespace()
-			if tk != IDENTIFIER && tk != DIGIT {
-				return nil, NewXParserError(ErrMsgBadGroup, literal)
-			}
-			// Check if the column exists as field.
-			groupBy, err := stmt.searchColumn(literal)
-			if err != nil {
-				return nil, NewXParserError(ErrMsgBadGroup, err.Error())
-			}
-			stmt.GroupBy = append(stmt.GroupBy, groupBy)
+		// If the next token is not a comma then break the loop.
+		if !p.accept(COMMA) {
+			break
+		}
+	}
+	if stmt.hasDuringParameter() {
+		if len(stmt.During) > 2 {
+			return NewXParserError(ErrMsgBadDuring, ErrMsgDuringSize)
+		}
+		return nil
+	}
+	// Checks expected bounds.
+	return checkDuring(stmt.During)
+}
 
-			// If the next token is not a comma then break the loop.
-			if tk, _ := p.scanIgnoreWhitespace(); tk != COMMA {
-				p.unscan()
-				break
-			}
+// parseGroupBy parses the columns of the GROUP BY clause.
+func (p *Parser) parseGroupBy(stmt *SelectStatement) error {
+	if tk, literal := p.scanIgnoreWhitespace(); tk != BY {
+		return NewXParserError(ErrMsgBadGroup, literal)
+	}
+	for {
+		// Read the field used to group.
+		tk, literal := p.scanIgnoreWhitespace()
+		if tk != IDENTIFIER && tk != DIGIT {
+			return NewXParserError(ErrMsgBadGroup, literal)
 		}
-	} else {
-		// No grouping clause.
-		p.unscan()
+		// Check if the column exists as field.
+		groupBy, err := stmt.searchColumn(literal)
+		if err != nil {
+			return wrapParserError(ErrMsgBadGroup, err)
+		}
+		stmt.GroupBy = append(stmt.GroupBy, groupBy)
+
+		// If the next token is not a comma then break the loop.
+		if !p.accept(COMMA) {
+			break
+		}
+	}
+	// Finally, we may find the WITH ROLLUP modifier.
+	if p.accept(WITH) {
+		if tk, literal := p.scanIgnoreWhitespace(); !isRollup(tk, literal) {
+			return NewXParserError(ErrMsgBadGroup, "WITH "+literal)
+		}
+		stmt.Rollup = true
 	}
+	return nil
+}
 
-	// Next we may see a "ORDER" keyword.
-	if tk, _ := p.scanIgnoreWhitespace(); tk == ORDER {
-		if tk, literal := p.scanIgnoreWhitespace(); tk != BY {
-			return nil, NewXParserError(ErrMsgBadOrder, literal)
+// isRollup returns true if the token is the ROLLUP modifier, whatever its case.
+// As the SQL verbs of writeVerbs, it is not a reserved word to be usable as column name.
+func isRollup(tk Token, literal string) bool {
+	return tk == IDENTIFIER && strings.EqualFold(literal, "ROLLUP")
+}
+
+// parseOrderBy parses the columns of the ORDER BY clause.
+func (p *Parser) parseOrderBy(stmt *SelectStatement) error {
+	if tk, literal := p.scanIgnoreWhitespace(); tk != BY {
+		return NewXParserError(ErrMsgBadOrder, literal)
+	}
+	for {
+		// Read the field used to order.
+		tk, literal := p.scanIgnoreWhitespace()
+		if tk != IDENTIFIER && tk != DIGIT {
+			return NewXParserError(ErrMsgBadOrder, literal)
 		}
-		for {
-			// Read the field used to order.
-			tk, literal := p.scanIgnoreWhitespace()
-			if tk != IDENTIFIER && tk != DIGIT {
-				return nil, NewXParserError(ErrMsgBadOrder, literal)
-			}
 
-			// Check if the column exists as field.
-			orderBy := &Order{}
-			column, err := stmt.searchColumn(literal)
-			if err != nil {
-				return nil, err
-			}
-			orderBy.ColumnPosition = column
+		// Check if the column exists as field.
+		orderBy := &Order{}
+		column, err := stmt.searchColumn(literal)
+		if err != nil {
+			return err
+		}
+		if stmt.sortedBy(column.Position()) {
+			return NewXParserError(ErrMsgDuplicateOrder, literal)
+		}
+		orderBy.ColumnPosition = column
+		orderBy.Index = len(stmt.OrderBy)
 
-			// Then, we may find a DESC or ASC keywords.
-			if tk, _ = p.scanIgnoreWhitespace(); tk == DESC {
-				orderBy.SortDesc = true
-			} else if tk != ASC {
-				p.unscan()
-			}
-			stmt.OrderBy = append(stmt.OrderBy, orderBy)
+		// Then, we may find a DESC or ASC keywords.
+		switch tk, literal = p.scanIgnoreWhitespace(); tk {
+		case DESC:
+			orderBy.SortDesc = true
+		case ASC:
+			orderBy.SortAsc = true
+		case IDENTIFIER:
+			// Misspelled sort order, like DES.
+			return NewXParserError(ErrMsgBadOrder, literal)
+		default:
+			p.unscan()
+		}
+		stmt.OrderBy = append(stmt.OrderBy, orderBy)
 
-			// If the next token is not a comma then break the loop.
-			if tk, _ := p.scanIgnoreWhitespace(); tk != COMMA {
-				p.unscan()
-				break
-			}
+		// If the next token is not a comma then break the loop.
+		if !p.accept(COMMA) {
+			return nil
 		}
-	} else {
-		// No ordering clause.
-		p.unscan()
 	}
+}
 
-	// Next we may see a "LIMIT" keyword.
-	if tk, _ := p.scanIgnoreWhitespace(); tk == LIMIT {
-		var literal string
-		if tk, literal = p.scanIgnoreWhitespace(); tk != DIGIT {
-			return nil, NewXParserError(ErrMsgBadLimit, literal)
+// parseLimit parses the start index and the row count of the LIMIT clause.
+func (p *Parser) parseLimit(stmt *SelectStatement) error {
+	offset, err := p.scanLimit()
+	if err != nil {
+		return err
+	}
+	stmt.WithRowCount = true
+
+	// If the next token is a comma then we should get the row count.
+	if tk, _ := p.scanIgnoreWhitespace(); tk == COMMA {
+		if stmt.RowCount, err = p.scanLimit(); err != nil {
+			return err
 		}
-		offset, _ := strconv.Atoi(literal)
-		stmt.WithRowCount = true
+		stmt.Offset, stmt.WithOffset = offset, true
+	} else {
+		// No row count value, so the offset is finally the row count.
+		stmt.RowCount = offset
+		p.unscan()
+	}
+	return nil
+}
 
-		// If the next token is a comma then we should get the row count.
-		if tk, _ := p.scanIgnoreWhitespace(); tk == COMMA {
-			tk, literal := p.scanIgnoreWhitespace()
-			if tk != DIGIT {
-				return nil, NewXParserError(ErrMsgBadLimit, stmt.RowCount)
-			}
-			stmt.Offset = offset
-			stmt.RowCount, _ = strconv.Atoi(literal)
+// scanLimit scans the next runes as an offset or a row count.
+// It must be a positive integer which fits in an int.
+func (p *Parser) scanLimit() (int, error) {
+	tk, literal := p.scanIgnoreWhitespace()
+	if tk == ILLEGAL && literal == "-" {
+		// Reports the negative number as a whole.
+		if tk, digits := p.scan(); tk == DIGIT {
+			literal += digits
 		} else {
-			// No row count value, so the offset is finally the row count.
-			stmt.RowCount = offset
 			p.unscan()
 		}
-	} else {
-		// No limit clause.
-		p.unscan()
+		return 0, NewXParserError(ErrMsgBadLimit, literal)
+	}
+	if tk != DIGIT {
+		return 0, NewXParserError(ErrMsgBadLimit, literal)
 	}
+	i, err := parseInt(literal)
+	if err != nil {
+		return 0, NewXParserError(ErrMsgBadLimit, literal)
+	}
+	return i, nil
+}
 
-	// Finally, we should find the end of the query.
-	var err error
-	if stmt.GModifier, err = p.scanQueryEnding(); err != nil {
-		return nil, err
+// checkDuring returns an error if the date range is not
+// a date range literal or a couple of ordered dates.
+func checkDuring(during []string) error {
+	var dateLiteral bool
+	for _, d := range during {
+		if isDateRangeLiteral(d) {
+			dateLiteral = true
+		} else if !isDate(d) {
+			return NewXParserError(ErrMsgBadDuring, d)
+		}
 	}
-	return stmt, nil
+	if rangeSize := len(during); rangeSize > 2 {
+		return NewXParserError(ErrMsgBadDuring, ErrMsgDuringSize)
+	} else if rangeSize == 1 && !dateLiteral {
+		return NewXParserError(ErrMsgBadDuring, ErrMsgDuringLitSize)
+	} else if rangeSize == 2 && dateLiteral {
+		return NewXParserError(ErrMsgBadDuring, ErrMsgDuringDateSize)
+	} else if rangeSize == 2 && during[0] > during[1] {
+		// Dates formatted as YYYYMMDD are sorted as strings.
+		return NewXParserError(ErrMsgBadDuring, ErrMsgDuringOrder)
+	}
+	return nil
 }
 
-// searchColumn returns the column matching the search expression.
+// searchColumn returns the column matching the search expression:
+// its position in the selected fields, its name or its alias.
 func (s SelectStatement) searchColumn(expr string) (*ColumnPosition, error) {
-	// If expr is a digit, search column by position.
-	if pos, err := strconv.Atoi(expr); err == nil {
-		if column, err := s.searchColumnByPosition(pos); err == nil {
-			return column, nil
-		}
+	pos := s.fieldPosition(expr)
+	if pos == 0 {
 		return nil, NewXParserError(ErrMsgBadColumn, expr)
 	}
+	return s.searchColumnByPosition(pos)
+}
+
+// fieldPosition returns the position of the field matching the search expression, 0 if none.
+func (s SelectStatement) fieldPosition(expr string) int {
+	// If expr is a digit, it is already a position.
+	if isDigits(expr) {
+		pos, err := parseInt(expr)
+		if err != nil || pos < 1 || pos > len(s.Fields) {
+			return 0
+		}
+		return pos
+	}
 	// Otherwise fetch each column to find it by name or alias.
 	for i, field := range s.Fields {
 		field := field.(*DynamicColumn)
 		if field.ColumnName == expr || field.ColumnAlias == expr {
-			return NewColumnPosition(field.Column, (i + 1)), nil
+			return i + 1
+		}
+	}
+	// As in SQL, the aliases are case-insensitive, unlike the column names.
+	for i, field := range s.Fields {
+		field := field.(*DynamicColumn)
+		if field.ColumnAlias != "" && strings.EqualFold(field.ColumnAlias, expr) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// parseInt returns the integer value of the digits.
+// An error is returned if the value overflows an int.
+func parseInt(digits string) (int, error) {
+	i, err := strconv.ParseInt(digits, 10, 0)
+	return int(i), err
+}
+
+// isDigits returns true if the string is only made of digits.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if !isDigit(r) {
+			return false
 		}
 	}
-	return nil, NewXParserError(ErrMsgBadColumn, expr)
+	return s != ""
+}
+
+// hasWildcard returns true if the rune '*' is used as field to select all the columns.
+func (s DataStatement) hasWildcard() bool {
+	for _, f := range s.Fields {
+		if _, _, ok := f.Aggregate(); !ok && f.Name() == "*" {
+			return true
+		}
+	}
+	return false
 }
 
 // searchColumnByPosition returns the column matching the search position.
@@ -583,12 +1228,18 @@ func (s DataStatement) searchColumnByPosition(pos int) (*ColumnPosition, error)
 // If a token has been unscanned then read that instead.
 func (p *Parser) scan() (Token, string) {
 	if p.buf.n != 0 {
-		p.buf.n = 0
+		p.buf.n--
 	} else {
 		// No token in the buffer so, read the next token from the scanner.
-		p.buf.t, p.buf.l = p.s.Scan()
+		p.buf.i = (p.buf.i + 1) % bufSize
+		tk, literal, pos := p.s.ScanPos()
+		p.buf.tk[p.buf.i], p.buf.lit[p.buf.i], p.buf.pos[p.buf.i] = tk, literal, pos
+		if p.opts.tokenObserver != nil && tk != WHITE_SPACE {
+			p.opts.tokenObserver(tk, literal, pos)
+		}
 	}
-	return p.buf.t, p.buf.l
+	i := (p.buf.i - p.buf.n + bufSize) % bufSize
+	return p.buf.tk[i], p.buf.lit[i]
 }
 
 // scanDistinct scans the next runes as column to use to group.
@@ -612,25 +1263,37 @@ func (p *Parser) scanIgnoreWhitespace() (tk Token, literal string) {
 	return
 }
 
-// scanList consumes all runes between left and right square brackets.
+// scanValueList consumes all runes between left and right square brackets.
 // Use comma as separator to return a list of string or literal value.
-func (p *Parser) scanValueList() (tk Token, list []string) {
+// The strings of the list are unquoted and unescaped, as the scanner returns them.
+// The token EOF is returned if the list is not closed, UNTERMINATED_STRING if one of its strings is not,
+// and RIGHT_SQUARE_BRACKETS if the list has no value, as [ ].
+// A comma missing between two values or before the first one is returned as error,
+// at the position of the value following the missing comma or of the leading one.
+func (p *Parser) scanValueList() (tk Token, list []string, err error) {
 	// A list must begin with a left square brackets.
 	if ctk, _ := p.scanIgnoreWhitespace(); ctk != LEFT_SQUARE_BRACKETS {
 		return
 	}
 	// Get all values of the list and names the loop on it: L
+	sep := true
 L:
 	for {
 		ctk, literal := p.scanIgnoreWhitespace()
 		switch ctk {
-		case EOF:
-			tk = ILLEGAL
+		case EOF, UNTERMINATED_STRING:
+			tk = ctk
 			break L
 		case RIGHT_SQUARE_BRACKETS:
-			// End of the list.
+			// End of the list, a trailing comma is tolerated.
+			if len(list) == 0 {
+				tk = RIGHT_SQUARE_BRACKETS
+			}
 			break L
-		case VALUE_LITERAL, IDENTIFIER, DECIMAL, DIGIT:
+		case VALUE_LITERAL, IDENTIFIER, DECIMAL, DIGIT, PLACEHOLDER, NAMED_PLACEHOLDER:
+			if !sep {
+				return tk, list, p.listError(ErrMsgMissingComma, literal)
+			}
 			// A list can only be string list or a value literal list but not the both.
 			if tk == STRING_LIST {
 				tk = ILLEGAL
@@ -639,6 +1302,9 @@ L:
 			// Consume as value literal.
 			tk = VALUE_LITERAL_LIST
 		case STRING:
+			if !sep {
+				return tk, list, p.listError(ErrMsgMissingComma, quoteString(literal))
+			}
 			// A list can only be string list or a value literal list but not the both.
 			if tk == VALUE_LITERAL_LIST {
 				tk = ILLEGAL
@@ -646,32 +1312,144 @@ L:
 			}
 			tk = STRING_LIST
 		case COMMA:
+			// Each value is separated by one comma, without comma before the first one.
+			if len(list) == 0 {
+				return tk, list, p.listError(ErrMsgLeadingComma, "[,")
+			}
+			if sep {
+				tk = ILLEGAL
+				break L
+			}
+			sep = true
 			continue L
 		default:
 			tk = ILLEGAL
 			break L
 		}
 		list = append(list, literal)
+		sep = false
 	}
 	return
 }
 
+// listError returns the error on a separator of a list of values,
+// at the position of the last token scanned.
+func (p *Parser) listError(text, arg string) error {
+	return &ParserError{s: formatError(text), a: arg, pos: p.pos()}
+}
+
 // scanQueryEnding scans the next runes as query ending.
-// Return true if vertical output is required or error if it is not the end of the query.
-func (p *Parser) scanQueryEnding() (bool, error) {
+// Return the terminator of the query or error if it is not the end of the query.
+func (p *Parser) scanQueryEnding() (Terminator, error) {
 	tk, literal := p.scanIgnoreWhitespace()
 	switch tk {
 	case G_MODIFIER:
-		return true, nil
-	case SEMICOLON, EOF:
-		return false, nil
+		// A semicolon may follow the G modifier.
+		p.accept(SEMICOLON)
+		return TerminatorG, nil
+	case SEMICOLON:
+		return TerminatorSemicolon, nil
+	case EOF:
+		// Keeps the end of the input to read for the next call.
+		p.unscan()
+		return TerminatorEOF, nil
 	default:
 		p.unscan()
 	}
-	return false, NewXParserError(ErrMsgSyntax, literal)
+	return TerminatorEOF, NewXParserError(ErrMsgSyntax, literal)
+}
+
+// canceled returns the error of the context of the parse, if any.
+func (p *Parser) canceled() error {
+	if p.ctx == nil {
+		return nil
+	}
+	return p.ctx.Err()
+}
+
+// exceeded returns a limit error instead of the result of the parse
+// when the input has been truncated to its maximum size,
+// or a token too long error, with the first runes of the token, if it failed on it.
+func (p *Parser) exceeded(err error) error {
+	tk, literal := p.lastRead()
+	if p.in != nil && p.in.exceeded && tk == EOF {
+		return &LimitError{Limit: "MaxInputBytes", Max: p.opts.maxInputBytes}
+	}
+	if tk == TOO_LONG && err != nil && err != io.EOF {
+		return NewXParserError(ErrMsgTokenTooLong, prefix(literal, tooLongPrefix))
+	}
+	return err
+}
+
+// tooLongPrefix is the number of runes of a token too long reported in the error.
+const tooLongPrefix = 32
+
+// prefix returns the n first runes of the string, followed by an ellipsis if it is longer.
+func prefix(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i] + "..."
+		}
+		n--
+	}
+	return s
+}
+
+// incomplete flags the parse error as raised by the end of the input,
+// when it occurs in the place of an expected token or inside a string.
+func (p *Parser) incomplete(err error) error {
+	if e, ok := err.(*ParserError); ok {
+		if tk, _ := p.lastRead(); (tk == EOF && p.buf.n == 0) || tk == UNTERMINATED_STRING {
+			e.incomplete = true
+		}
+	}
+	return err
 }
 
 // unscan pushes the previously read token back onto the buffer.
 func (p *Parser) unscan() {
-	p.buf.n = 1
+	p.unscanN(1)
+}
+
+// unscanN pushes the k previously read tokens back onto the buffer.
+// The peek method bounds k to stay within the buffer.
+func (p *Parser) unscanN(k int) {
+	p.buf.n += k
+}
+
+// peek returns the k-th next non-whitespace token, starting at 1, without consuming it.
+// It looks ahead at most maxLookahead tokens: beyond, the last one looked ahead is returned.
+func (p *Parser) peek(k int) (tk Token, literal string) {
+	if k > maxLookahead {
+		k = maxLookahead
+	}
+	var read int
+	for ; k > 0 && tk != EOF; k-- {
+		if tk, literal = p.scan(); tk == WHITE_SPACE {
+			tk, literal = p.scan()
+			read++
+		}
+		read++
+	}
+	p.unscanN(read)
+	return
+}
+
+// accept consumes the next non-whitespace token if it is the expected one.
+func (p *Parser) accept(tk Token) bool {
+	if next, _ := p.peek(1); next != tk {
+		return false
+	}
+	p.scanIgnoreWhitespace()
+	return true
+}
+
+// pos returns the position of the token returned by the last call to scan.
+func (p *Parser) pos() Pos {
+	return p.buf.pos[(p.buf.i-p.buf.n+bufSize)%bufSize]
+}
+
+// lastRead returns the last token read from the scanner, even if it has been pushed back.
+func (p *Parser) lastRead() (Token, string) {
+	return p.buf.tk[p.buf.i], p.buf.lit[p.buf.i]
 }
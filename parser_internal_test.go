@@ -61,7 +61,10 @@ func TestParser_ParseCreateView(t *testing.T) {
 		// Errors
 		{q: `SELECT`, err: NewXParserError(ErrMsgBadMethod, "SELECT")},
 		{q: `CREATE VIEW !`, err: NewXParserError(ErrMsgBadSrc, "!")},
+		{q: `CREATE VIEW CAMPAIGN_DAILY AS SELECT * FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgWildcardView, "*")},
+		{q: `CREATE VIEW CAMPAIGN_DAILY (Name) AS SELECT * FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgWildcardView, "*")},
 		{q: `CREATE VIEW CAMPAIGN_DAILY (Name, Cost) AS SELECT SUM(DISTINCT Cost) FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewParserError(ErrMsgColumnsNotMatch)},
+		{q: `CREATE VIEW CAMPAIGN_DAILY (Name) AS SELECT CampaignName, Cost FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewParserError(ErrMsgColumnsNotMatch)},
 	}
 
 	for i, qt := range queryTests {
@@ -115,7 +118,22 @@ func TestParser_ParseDescribe(t *testing.T) {
 						&DynamicColumn{&Column{ColumnName: "CampaignName"}, "", false},
 					},
 					TableName: "CAMPAIGN_PERFORMANCE_REPORT",
-					Statement: Statement{GModifier: true},
+					Statement: Statement{End: TerminatorG, GModifier: true},
+				},
+			},
+		},
+
+		// Table and column names separated by a dot.
+		{
+			q: `DESC FULL CAMPAIGN_PERFORMANCE_REPORT.CampaignName;`,
+			stmt: &DescribeStatement{
+				FullStatement: FullStatement{Full: true},
+				DataStatement: DataStatement{
+					Fields: []DynamicField{
+						&DynamicColumn{&Column{ColumnName: "CampaignName"}, "", false},
+					},
+					TableName: "CAMPAIGN_PERFORMANCE_REPORT",
+					Statement: Statement{End: TerminatorSemicolon},
 				},
 			},
 		},
@@ -123,6 +141,12 @@ func TestParser_ParseDescribe(t *testing.T) {
 		// Errors
 		{q: `SELECT`, err: NewXParserError(ErrMsgBadMethod, "SELECT")},
 		{q: `DESC !`, err: NewXParserError(ErrMsgBadSrc, "!")},
+		{q: `DESC FROM CAMPAIGN_PERFORMANCE_REPORT`, err: newHintParserError(ErrMsgUnexpectedFrom, "FROM", "DESC CAMPAIGN_PERFORMANCE_REPORT")},
+		{q: `DESC FULL FROM R`, err: newHintParserError(ErrMsgUnexpectedFrom, "FROM", "DESC FULL R")},
+		{q: `DESC FROM`, err: NewXParserError(ErrMsgUnexpectedFrom, "FROM")},
+		{q: `DESC R.C.D`, err: NewXParserError(ErrMsgBadSrc, "R.C.D")},
+		{q: `DESC R.`, err: NewXParserError(ErrMsgBadSrc, "R.")},
+		{q: `DESC R.C CampaignName`, err: NewXParserError(ErrMsgSyntax, "CampaignName")},
 	}
 
 	for i, qt := range queryTests {
@@ -157,7 +181,7 @@ func TestParser_ParseShow(t *testing.T) {
 			q: `SHOW FULL TABLES\G`,
 			stmt: &ShowStatement{
 				FullStatement: FullStatement{Full: true},
-				Statement:     Statement{GModifier: true},
+				Statement:     Statement{End: TerminatorG, GModifier: true},
 			},
 		},
 
@@ -165,7 +189,7 @@ func TestParser_ParseShow(t *testing.T) {
 		{
 			q: `SHOW TABLES LIKE 'CAMPAIGN%'\G`,
 			stmt: &ShowStatement{
-				Statement: Statement{GModifier: true},
+				Statement: Statement{End: TerminatorG, GModifier: true},
 				Like:      Pattern{Prefix: "CAMPAIGN"},
 			},
 		},
@@ -174,7 +198,7 @@ func TestParser_ParseShow(t *testing.T) {
 		{
 			q: `SHOW TABLES LIKE '%REPORT'\G`,
 			stmt: &ShowStatement{
-				Statement: Statement{GModifier: true},
+				Statement: Statement{End: TerminatorG, GModifier: true},
 				Like:      Pattern{Suffix: "REPORT"},
 			},
 		},
@@ -191,16 +215,40 @@ func TestParser_ParseShow(t *testing.T) {
 		{
 			q: `SHOW TABLES LIKE 'LABEL';`,
 			stmt: &ShowStatement{
-				Like: Pattern{Equal: "LABEL"},
+				Statement: Statement{End: TerminatorSemicolon},
+				Like:      Pattern{Equal: "LABEL"},
+			},
+		},
+
+		// Show statement like something with a wildcard inside.
+		{
+			q: `SHOW TABLES LIKE 'CAMPAIGN%REPORT'`,
+			stmt: &ShowStatement{
+				Like: Pattern{Expr: "CAMPAIGN%REPORT"},
 			},
 		},
 
+		// Show statement like something with multiple wildcards.
+		{
+			q: `SHOW TABLES LIKE '%AD%PERFORMANCE%'`,
+			stmt: &ShowStatement{
+				Like: Pattern{Expr: "%AD%PERFORMANCE%"},
+			},
+		},
+
+		// Show statement like anything.
+		{
+			q:    `SHOW TABLES LIKE '%'`,
+			stmt: &ShowStatement{},
+		},
+
 		// Show statement with a specific column.
 		{
 			q: `SHOW TABLES WITH CampaignName;`,
 			stmt: &ShowStatement{
-				With:    "CampaignName",
-				UseWith: true,
+				Statement: Statement{End: TerminatorSemicolon},
+				With:      "CampaignName",
+				UseWith:   true,
 			},
 		},
 
@@ -208,8 +256,9 @@ func TestParser_ParseShow(t *testing.T) {
 		{
 			q: `SHOW TABLES WITH "CampaignName";`,
 			stmt: &ShowStatement{
-				With:    "CampaignName",
-				UseWith: true,
+				Statement: Statement{End: TerminatorSemicolon},
+				With:      "CampaignName",
+				UseWith:   true,
 			},
 		},
 
@@ -217,8 +266,9 @@ func TestParser_ParseShow(t *testing.T) {
 		{
 			q: `SHOW TABLES WITH "";`,
 			stmt: &ShowStatement{
-				With:    "",
-				UseWith: true,
+				Statement: Statement{End: TerminatorSemicolon},
+				With:      "",
+				UseWith:   true,
 			},
 		},
 
@@ -227,6 +277,7 @@ func TestParser_ParseShow(t *testing.T) {
 		{q: `SHOW`, err: NewXParserError(ErrMsgSyntax, "")},
 		{q: `SHOW TABLES LIKE rv`, err: NewXParserError(ErrMsgSyntax, "rv")},
 		{q: `SHOW TABLES LABEL`, err: NewXParserError(ErrMsgSyntax, "LABEL")},
+		{q: `SHOW TABLES LIKE 'CAMPAIGN%`, err: NewXParserError(ErrMsgUnterminatedString, "'CAMPAIGN%")},
 	}
 
 	for i, qt := range queryTests {
@@ -274,7 +325,7 @@ func TestParser_ParseSelect(t *testing.T) {
 						&DynamicColumn{&Column{ColumnName: "Cost"}, "", false},
 					},
 					TableName: "CAMPAIGN_PERFORMANCE_REPORT",
-					Statement: Statement{GModifier: true},
+					Statement: Statement{End: TerminatorG, GModifier: true},
 				},
 			},
 		},
@@ -288,6 +339,7 @@ func TestParser_ParseSelect(t *testing.T) {
 						&DynamicColumn{&Column{ColumnName: "*"}, "", false},
 					},
 					TableName: "CAMPAIGN_DAILY",
+					Statement: Statement{End: TerminatorSemicolon},
 				},
 				Where: []Condition{
 					&Where{&Column{ColumnName: "CampaignId"}, "=", []string{"12345678"}, true},
@@ -296,6 +348,32 @@ func TestParser_ParseSelect(t *testing.T) {
 			},
 		},
 
+		// Select statement with date range literals in lower or mixed case.
+		{
+			q: `SELECT * FROM CAMPAIGN_DAILY during yesterday`,
+			stmt: &SelectStatement{
+				DataStatement: DataStatement{
+					Fields: []DynamicField{
+						&DynamicColumn{&Column{ColumnName: "*"}, "", false},
+					},
+					TableName: "CAMPAIGN_DAILY",
+				},
+				During: []string{"YESTERDAY"},
+			},
+		},
+		{
+			q: `SELECT * FROM CAMPAIGN_DAILY DURING Last_7_Days`,
+			stmt: &SelectStatement{
+				DataStatement: DataStatement{
+					Fields: []DynamicField{
+						&DynamicColumn{&Column{ColumnName: "*"}, "", false},
+					},
+					TableName: "CAMPAIGN_DAILY",
+				},
+				During: []string{"LAST_7_DAYS"},
+			},
+		},
+
 		// Select statement with aggregate function and alias with row count limit.
 		{
 			q: `SELECT MAX(Cost) as max FROM CAMPAIGN_PERFORMANCE_REPORT LIMIT 5\G`,
@@ -305,9 +383,9 @@ func TestParser_ParseSelect(t *testing.T) {
 						&DynamicColumn{&Column{ColumnName: "Cost", ColumnAlias: "max"}, "MAX", false},
 					},
 					TableName: "CAMPAIGN_PERFORMANCE_REPORT",
-					Statement: Statement{GModifier: true},
+					Statement: Statement{End: TerminatorG, GModifier: true},
 				},
-				Limit: Limit{0, 5, true},
+				Limit: Limit{0, 5, true, false},
 			},
 		},
 
@@ -330,15 +408,17 @@ func TestParser_ParseSelect(t *testing.T) {
 			stmt: &SelectStatement{
 				DataStatement: DataStatement{
 					Fields: []DynamicField{
-						&DynamicColumn{&Column{ColumnName: "Cost", ColumnAlias: "c"}, "", true},
+						&DynamicColumn{&Column{ColumnName: "Cost", ColumnAlias: "c"}, "", false},
 					},
 					TableName: "CAMPAIGN_PERFORMANCE_REPORT",
+					Statement: Statement{End: TerminatorSemicolon},
 				},
+				Unique: true,
 				During: []string{"20161224", "20161224"},
 				OrderBy: []Orderer{
-					&Order{&ColumnPosition{&Column{ColumnName: "Cost", ColumnAlias: "c"}, 1}, true},
+					&Order{&ColumnPosition{&Column{ColumnName: "Cost", ColumnAlias: "c"}, 1}, true, false, 0},
 				},
-				Limit: Limit{15, 5, true},
+				Limit: Limit{15, 5, true, true},
 			},
 		},
 
@@ -352,6 +432,7 @@ func TestParser_ParseSelect(t *testing.T) {
 						&DynamicColumn{&Column{ColumnName: "Cost"}, "", false},
 					},
 					TableName: "CAMPAIGN_PERFORMANCE_REPORT",
+					Statement: Statement{End: TerminatorSemicolon},
 				},
 				Where: []Condition{
 					&Where{&Column{ColumnName: "CampaignStatus"}, "IN", []string{"ENABLED", "PAUSED"}, false},
@@ -363,6 +444,27 @@ func TestParser_ParseSelect(t *testing.T) {
 			},
 		},
 
+		// Select statement grouping by alias, position and name.
+		{
+			q: `SELECT CampaignName AS campaign, AdGroupName, Device, SUM(Cost) FROM ADGROUP_PERFORMANCE_REPORT GROUP BY Campaign, 2, Device`,
+			stmt: &SelectStatement{
+				DataStatement: DataStatement{
+					Fields: []DynamicField{
+						&DynamicColumn{&Column{ColumnName: "CampaignName", ColumnAlias: "campaign"}, "", false},
+						&DynamicColumn{&Column{ColumnName: "AdGroupName"}, "", false},
+						&DynamicColumn{&Column{ColumnName: "Device"}, "", false},
+						&DynamicColumn{&Column{ColumnName: "Cost"}, "SUM", false},
+					},
+					TableName: "ADGROUP_PERFORMANCE_REPORT",
+				},
+				GroupBy: []FieldPosition{
+					&ColumnPosition{&Column{ColumnName: "CampaignName", ColumnAlias: "campaign"}, 1},
+					&ColumnPosition{&Column{ColumnName: "AdGroupName"}, 2},
+					&ColumnPosition{&Column{ColumnName: "Device"}, 3},
+				},
+			},
+		},
+
 		// Select statement with value literal list and EOF as ending.
 		{
 			q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId IN [123456789,987654321]`,
@@ -378,6 +480,37 @@ func TestParser_ParseSelect(t *testing.T) {
 				},
 			},
 		},
+		{
+			q: `SELECT Criteria FROM CRITERIA_PERFORMANCE_REPORT WHERE IsNegative = true AND Status IN [True, FALSE]`,
+			stmt: &SelectStatement{
+				DataStatement: DataStatement{
+					Fields: []DynamicField{
+						&DynamicColumn{&Column{ColumnName: "Criteria"}, "", false},
+					},
+					TableName: "CRITERIA_PERFORMANCE_REPORT",
+				},
+				Where: []Condition{
+					&Where{&Column{ColumnName: "IsNegative"}, "=", []string{"TRUE"}, true},
+					&Where{&Column{ColumnName: "Status"}, "IN", []string{"TRUE", "FALSE"}, true},
+				},
+			},
+		},
+		{
+			q: `SELECT Date FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Date >= '2016-12-01' AND Date IN ["2016-12-24", "2016-12-31"] AND Week = '2016-12-26'`,
+			stmt: &SelectStatement{
+				DataStatement: DataStatement{
+					Fields: []DynamicField{
+						&DynamicColumn{&Column{ColumnName: "Date"}, "", false},
+					},
+					TableName: "CAMPAIGN_PERFORMANCE_REPORT",
+				},
+				Where: []Condition{
+					&Where{&Column{ColumnName: "Date"}, ">=", []string{"20161201"}, true},
+					&Where{&Column{ColumnName: "Date"}, "IN", []string{"20161224", "20161231"}, true},
+					&Where{&Column{ColumnName: "Week"}, "=", []string{"2016-12-26"}, false},
+				},
+			},
+		},
 
 		// Errors
 		{q: `DELETE`, err: NewXParserError(ErrMsgBadMethod, "DELETE")},
@@ -387,29 +520,75 @@ func TestParser_ParseSelect(t *testing.T) {
 		{q: `SELECT CampaignId FROM REPORT WHERE`, err: NewXParserError(ErrMsgBadField, "")},
 		{q: `SELECT CampaignId FROM REPORT GROUP`, err: NewXParserError(ErrMsgBadGroup, "")},
 		{q: `SELECT CampaignId FROM REPORT GROUP BY ,`, err: NewXParserError(ErrMsgBadGroup, ",")},
-		{q: `SELECT CampaignId FROM REPORT GROUP BY 2`, err: NewXParserError(ErrMsgBadGroup, NewXParserError(ErrMsgBadColumn, "2"))},
+		{q: `SELECT CampaignId FROM REPORT GROUP BY 2`, err: wrapParserError(ErrMsgBadGroup, NewXParserError(ErrMsgBadColumn, "2"))},
 		{q: `SELECT CampaignId FROM REPORT ORDER 1`, err: NewXParserError(ErrMsgBadOrder, "1")},
 		{q: `SELECT CampaignId FROM REPORT LIMIT 1 SELECT`, err: NewXParserError(ErrMsgSyntax, "SELECT")},
 		{q: `SELECT CampaignId FROM REPORT LIMIT`, err: NewXParserError(ErrMsgBadLimit, "")},
+		{q: `SELECT CampaignId FROM REPORT LIMIT 99999999999999999999`, err: NewXParserError(ErrMsgBadLimit, "99999999999999999999")},
+		{q: `SELECT CampaignId FROM REPORT LIMIT 5, 99999999999999999999`, err: NewXParserError(ErrMsgBadLimit, "99999999999999999999")},
+		{q: `SELECT CampaignId FROM REPORT LIMIT 10, x`, err: NewXParserError(ErrMsgBadLimit, "x")},
+		{q: `SELECT CampaignId FROM REPORT LIMIT 10,`, err: NewXParserError(ErrMsgBadLimit, "")},
+		{q: `SELECT CampaignId FROM REPORT LIMIT -1`, err: NewXParserError(ErrMsgBadLimit, "-1")},
+		{q: `SELECT CampaignId FROM REPORT LIMIT 10, -5`, err: NewXParserError(ErrMsgBadLimit, "-5")},
+		{q: `SELECT CampaignId FROM REPORT LIMIT - 5`, err: NewXParserError(ErrMsgBadLimit, "-")},
+		{q: `SELECT CampaignId FROM REPORT LIMIT 1.5`, err: NewXParserError(ErrMsgBadLimit, "1.5")},
+		{q: `SELECT CampaignId FROM REPORT LIMIT 'rv'`, err: NewXParserError(ErrMsgBadLimit, "rv")},
+		{q: `SELECT CampaignId FROM REPORT ORDER BY 99999999999999999999`, err: NewXParserError(ErrMsgBadColumn, "99999999999999999999")},
 		{q: `SELECT DISTINCT 1 FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgBadField, "1")},
+		{q: `SELECT Cost, DISTINCT Clicks FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgBadField, "DISTINCT")},
+		{q: `SELECT *, Cost FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgMixedWildcard, "*")},
+		{q: `SELECT Cost EXCEPT (Clicks) FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgSyntax, "EXCEPT")},
+		{q: `SELECT * EXCEPT () FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgSyntax, ")")},
+		{q: `SELECT * EXCEPT Cost FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgSyntax, "Cost")},
+		{q: `SELECT * EXCEPT (Cost, ) FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgSyntax, ")")},
+		{q: `SELECT * EXCEPT (Cost Clicks) FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgSyntax, "Clicks")},
+		{q: `SELECT Cost, * FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgMixedWildcard, "*")},
+		{q: `SELECT COUNT(*), * FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgMixedWildcard, "*")},
 		{q: `SELECT rv(Cost) FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgBadFunc, "rv")},
+		{q: `SELECT SUM(2), Cost FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgForwardPosition, "2")},
+		{q: `SELECT Cost, SUM(2) FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgForwardPosition, "2")},
+		{q: `SELECT Cost, SUM(0) FROM CAMPAIGN_PERFORMANCE_REPORT`, err: NewXParserError(ErrMsgSyntax, "0")},
 		{q: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName ! "rv"`, err: NewXParserError(ErrMsgSyntax, "!")},
 		{q: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName = !`, err: NewXParserError(ErrMsgSyntax, "!")},
 		{q: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName IN [ !`, err: NewXParserError(ErrMsgSyntax, "[")},
 		{q: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING`, err: NewXParserError(ErrMsgBadDuring, "")},
 		{q: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING RV`, err: NewXParserError(ErrMsgBadDuring, "RV")},
+		{q: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING last_seven_days`, err: NewXParserError(ErrMsgBadDuring, "last_seven_days")},
 		{q: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING TODAY, YESTERDAY`, err: NewXParserError(ErrMsgBadDuring, ErrMsgDuringDateSize)},
 		{q: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING 201612`, err: NewXParserError(ErrMsgBadDuring, "201612")},
 		{q: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING 20161224`, err: NewXParserError(ErrMsgBadDuring, ErrMsgDuringLitSize)},
 		{q: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING 20161224,20161225,20161226`, err: NewXParserError(ErrMsgBadDuring, ErrMsgDuringSize)},
+		{q: `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING 20991231,20160101`, err: NewXParserError(ErrMsgBadDuring, ErrMsgDuringOrder)},
 		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus IN ["ENABLED",PAUSED];`, err: NewXParserError(ErrMsgSyntax, "[")},
 		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus IN [PAUSED,"ENABLED"];`, err: NewXParserError(ErrMsgSyntax, "[")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Cost > 1.2.3`, err: NewXParserError(ErrMsgSyntax, "1.2.3")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Cost > 123abc`, err: NewXParserError(ErrMsgSyntax, "abc")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus = DURING LAST_7_DAYS`, err: NewXParserError(ErrMsgValueExpected, "DURING")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus = order by 1`, err: NewXParserError(ErrMsgValueExpected, "ORDER")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId IN [`, err: NewXParserError(ErrMsgUnterminatedList, "[")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId IN [1, 2`, err: NewXParserError(ErrMsgUnterminatedList, "[1,2")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId IN []`, err: NewXParserError(ErrMsgEmptyList, "[]")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId IN [ , , ]`, err: &ParserError{s: formatError(ErrMsgLeadingComma), a: "[,", pos: Pos{Line: 1}}},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId IN [ , 1]`, err: &ParserError{s: formatError(ErrMsgLeadingComma), a: "[,", pos: Pos{Line: 1}}},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName = 'oops`, err: NewXParserError(ErrMsgUnterminatedString, "'oops")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName IN ["rv", "oops`, err: NewXParserError(ErrMsgUnterminatedString, `"oops`)},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT DURING LAST_7_DAYS WHERE Clicks > 0`, err: NewXParserError(ErrMsgClauseOrder, "WHERE after DURING")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT ORDER BY 1 GROUP BY 1`, err: NewXParserError(ErrMsgClauseOrder, "GROUP BY after ORDER BY")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT LIMIT 5 LIMIT 10`, err: NewXParserError(ErrMsgDuplicateClause, "LIMIT")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0 WHERE Cost > 0`, err: NewXParserError(ErrMsgDuplicateClause, "WHERE")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1 ORDER BY 1 GROUP BY 1`, err: NewXParserError(ErrMsgDuplicateClause, "GROUP BY")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = [1, 2]`, err: NewXParserError(ErrMsgOperatorValue, "= expects a single value")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName contains ["rv"]`, err: NewXParserError(ErrMsgOperatorValue, "CONTAINS expects a single value")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus IN 'ENABLED'`, err: NewXParserError(ErrMsgOperatorValue, "IN expects a list")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId NOT_IN 1`, err: NewXParserError(ErrMsgOperatorValue, "NOT_IN expects a list")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Date = '2016-13-40'`, err: NewXParserError(ErrMsgBadDate, "2016-13-40")},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Date IN ['2016-12-24', '2016-02-30']`, err: NewXParserError(ErrMsgBadDate, "2016-02-30")},
 	}
 
 	for i, qt := range queryTests {
 		stmt, err := NewParser(strings.NewReader(qt.q)).ParseSelect()
 		if err != nil {
-			if qt.err.Error() != err.Error() {
+			if qt.err == nil || qt.err.Error() != err.Error() {
 				t.Errorf("%d. Expected the error message %v with %s, received %v", i, qt.err, qt.q, err.Error())
 			}
 		} else if qt.err != nil {
@@ -419,3 +598,111 @@ func TestParser_ParseSelect(t *testing.T) {
 		}
 	}
 }
+
+// Ensure the parser can parse strings into USE Statement.
+func TestParser_ParseUse(t *testing.T) {
+	var queryTests = []struct {
+		q    string
+		stmt *UseStatement
+		err  error
+	}{
+		// Dash-separated client customer ID.
+		{
+			q:    `USE 123-456-7890;`,
+			stmt: &UseStatement{Account: "123-456-7890", Statement: Statement{End: TerminatorSemicolon}},
+		},
+
+		// Quoted client customer ID with vertical display.
+		{
+			q: `use '123-456-7890'\G`,
+			stmt: &UseStatement{
+				Account:   "123-456-7890",
+				Statement: Statement{End: TerminatorG, GModifier: true},
+			},
+		},
+
+		// Client customer ID without dash.
+		{
+			q:    `USE 1234567890`,
+			stmt: &UseStatement{Account: "1234567890"},
+		},
+
+		// Errors
+		{q: `SELECT`, err: NewXParserError(ErrMsgBadMethod, "SELECT")},
+		{q: `USE`, err: NewXParserError(ErrMsgBadAccount, "")},
+		{q: `USE rv`, err: NewXParserError(ErrMsgBadAccount, "rv")},
+		{q: `USE 123-rv`, err: NewXParserError(ErrMsgBadAccount, "123-rv")},
+		{q: `USE 123 456`, err: NewXParserError(ErrMsgSyntax, "456")},
+	}
+
+	for i, qt := range queryTests {
+		stmt, err := NewParser(strings.NewReader(qt.q)).ParseUse()
+		if err != nil {
+			if qt.err == nil || qt.err.Error() != err.Error() {
+				t.Errorf("%d. Expected the error message %v with %s, received %v", i, qt.err, qt.q, err.Error())
+			}
+		} else if qt.err != nil {
+			t.Errorf("%d. Expected the error message %v with %s, received no error", i, qt.err, qt.q)
+		} else if !reflect.DeepEqual(qt.stmt, stmt) {
+			t.Errorf("%d. Expected %#v, received %#v", i, qt.stmt, stmt)
+		}
+	}
+}
+
+// Ensure the WITH clause is reported as used even with an empty column name.
+func TestShowStatement_WithFieldName(t *testing.T) {
+	var tests = []struct {
+		q          string
+		name       string
+		used, like bool
+	}{
+		{q: `SHOW TABLES`},
+		{q: `SHOW TABLES WITH ""`, used: true},
+		{q: `SHOW TABLES WITH ''`, used: true},
+		{q: `SHOW TABLES WITH CampaignName`, name: "CampaignName", used: true},
+		{q: `SHOW TABLES LIKE 'CAMPAIGN%'`, like: true},
+	}
+
+	for i, tt := range tests {
+		stmt, err := NewParser(strings.NewReader(tt.q)).ParseShow()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %s, received %v", i, tt.q, err)
+		}
+		if name, used := stmt.WithFieldName(); name != tt.name || used != tt.used {
+			t.Errorf("%d. Expected (%q, %v) with %s, received (%q, %v)", i, tt.name, tt.used, tt.q, name, used)
+		}
+		if _, like := stmt.LikePattern(); like != tt.like {
+			t.Errorf("%d. Expected like clause %v with %s, received %v", i, tt.like, tt.q, like)
+		}
+	}
+}
+
+// Ensure the parser looks ahead several tokens without consuming them.
+func TestParser_peek(t *testing.T) {
+	p := NewParser(strings.NewReader(`SELECT Cost AS c`))
+	if tk, lit := p.peek(3); tk != AS || lit != "AS" {
+		t.Errorf("Expected the token AS, received %v (%q)", tk, lit)
+	}
+	if tk, _ := p.peek(5); tk != EOF {
+		t.Errorf("Expected the end of the input, received %v", tk)
+	}
+	if !p.accept(SELECT) || p.accept(AS) {
+		t.Error("Expected to only accept the next token")
+	}
+	p.scanIgnoreWhitespace()
+	p.scanIgnoreWhitespace()
+	p.unscanN(3)
+	if tk, lit := p.scanIgnoreWhitespace(); tk != IDENTIFIER || lit != "Cost" {
+		t.Errorf("Expected the identifier Cost, received %v (%q)", tk, lit)
+	}
+	// Beyond the buffer, the parser looks ahead as far as it can.
+	p = NewParser(strings.NewReader(`a b c d e f g h i j`))
+	p.scan()
+	p.unscan()
+	if tk, lit := p.peek(bufSize); tk != IDENTIFIER || lit != "g" {
+		t.Errorf("Expected the identifier g, received %v (%q)", tk, lit)
+	}
+	if tk, lit := p.scanIgnoreWhitespace(); tk != IDENTIFIER || lit != "a" {
+		t.Errorf("Expected the identifier a, received %v (%q)", tk, lit)
+	}
+}
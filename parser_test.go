@@ -2,7 +2,11 @@ package awqlparse_test
 
 import (
 	"fmt"
+	"io"
+	"reflect"
 	"strings"
+	"testing"
+	"testing/iotest"
 
 	awql "github.com/rvflash/awql-parser"
 )
@@ -43,3 +47,660 @@ func ExampleParser_ParseSelect() {
 	fmt.Printf("Gets the column named %v from %v.\n", stmt.Columns()[0].Name(), stmt.SourceName())
 	// Output: Gets the column named AdGroupName from ADGROUP_PERFORMANCE_REPORT.
 }
+
+func TestParser_ParseGModifier(t *testing.T) {
+	q := `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT LIMIT 5\gDESC ADGROUP_PERFORMANCE_REPORT\GSHOW TABLES`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	if len(stmts) != 3 {
+		t.Fatalf("Expected 3 statements, received %d", len(stmts))
+	}
+	for i, vertical := range []bool{true, true, false} {
+		if stmts[i].VerticalOutput() != vertical {
+			t.Errorf("%d. Expected the vertical output to be %v", i, vertical)
+		}
+	}
+}
+
+func TestParser_ParseCRLF(t *testing.T) {
+	q := "\ufeffSELECT CampaignName\r\nFROM CAMPAIGN_PERFORMANCE_REPORT\r\nWHERE Clicks > 0;\r\n" +
+		"DESC ADGROUP_PERFORMANCE_REPORT;\r\n"
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	if len(stmts) != 2 {
+		t.Fatalf("Expected 2 statements, received %d", len(stmts))
+	}
+	if s := stmts[0].String(); s != "SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0" {
+		t.Errorf("Unexpected first statement: %v", s)
+	}
+}
+
+func TestParser_ParseUnicode(t *testing.T) {
+	var tests = []struct {
+		q, s string
+	}{
+		{q: `SELECT Cost AS coût FROM CAMPAIGN_PERFORMANCE_REPORT`},
+		{q: `SELECT Coût, 名前 FROM 報告書 WHERE Coût > 0 ORDER BY 名前`, s: `SELECT Coût, 名前 FROM 報告書 WHERE Coût > 0 ORDER BY 2`},
+		{q: `CREATE VIEW vüe (café) AS SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT`},
+		{q: `DESC 報告書 名前`},
+		{q: `select cost as Été from rapport`, s: `SELECT cost AS Été FROM rapport`},
+	}
+	for i, tt := range tests {
+		stmt, err := awql.NewParser(strings.NewReader(tt.q)).ParseRow()
+		if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.q, err)
+			continue
+		}
+		if tt.s == "" {
+			tt.s = tt.q
+		}
+		if s := stmt.String(); s != tt.s {
+			t.Errorf("%d. Expected %q, received %q", i, tt.s, s)
+		}
+	}
+}
+
+// Ensure the parser can parse a select statement given as string.
+func ExampleParseSelectString() {
+	stmt, _ := awql.ParseSelectString(`SELECT AdGroupName FROM ADGROUP_PERFORMANCE_REPORT;`)
+	fmt.Println(stmt.SourceName())
+	// Output: ADGROUP_PERFORMANCE_REPORT
+}
+
+func TestParseString(t *testing.T) {
+	var tests = []string{
+		"SELECT Cost FROM REPORT WHERE Name = 'rv' DURING TODAY\\GDESC REPORT",
+		"SHOW FULL TABLES LIKE 'CAMPAIGN%'; USE 123-456-7890",
+		"CREATE VIEW rv AS SELECT Cost FROM REPORT",
+	}
+	for i, q := range tests {
+		stmts, err := awql.ParseString(q)
+		if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, q, err)
+			continue
+		}
+		// The statements must be the same as the ones parsed from a reader
+		// which can not unread, the scanner must use its buffer.
+		rs, err := awql.NewParser(iotest.OneByteReader(strings.NewReader(q))).Parse()
+		if err != nil || len(rs) != len(stmts) {
+			t.Errorf("%d. Expected %d statements with %q, received %v", i, len(stmts), q, err)
+			continue
+		}
+		for j := range rs {
+			if rs[j].String() != stmts[j].String() {
+				t.Errorf("%d. Expected %q, received %q", i, rs[j], stmts[j])
+			}
+		}
+	}
+	if _, err := awql.ParseRowString("SELEC Cost FROM REPORT"); err == nil {
+		t.Error("Expected an error with an unknown statement")
+	}
+	if stmt, err := awql.ParseDescribeString("DESC FULL REPORT Cost"); err != nil || !stmt.FullMode() {
+		t.Errorf("Expected a full DESC statement, received %v", err)
+	}
+	if _, err := awql.ParseCreateViewString("CREATE VIEW rv"); err == nil {
+		t.Error("Expected an error with an incomplete view")
+	}
+	if stmt, err := awql.ParseShowString("SHOW TABLES"); err != nil || stmt.FullMode() {
+		t.Errorf("Expected a SHOW statement, received %v", err)
+	}
+	if _, err := awql.ParseUseString("USE"); err == nil {
+		t.Error("Expected an error without account")
+	}
+}
+
+func TestParser_ParseNext(t *testing.T) {
+	q := "SELECT Cost FROM REPORT; SELEC Cost FROM REPORT; DESC REPORT\\G " +
+		"SELECT Cost FROM REPORT WHERE ;;SHOW TABLES\n"
+	var tests = []struct {
+		s   string
+		err bool
+	}{
+		{s: "SELECT Cost FROM REPORT"},
+		{err: true},
+		{s: "DESC REPORT"},
+		{err: true},
+		{err: true},
+		{s: "SHOW TABLES"},
+	}
+	p := awql.NewParser(strings.NewReader(q))
+	for i, tt := range tests {
+		stmt, err := p.ParseNext()
+		if tt.err {
+			if err == nil {
+				t.Errorf("%d. Expected an error, received %v", i, stmt)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d. Expected no error, received %v", i, err)
+		} else if s := stmt.String(); s != tt.s {
+			t.Errorf("%d. Expected %q, received %q", i, tt.s, s)
+		}
+	}
+	if _, err := p.ParseNext(); err != io.EOF {
+		t.Errorf("Expected the end of the input, received %v", err)
+	}
+	if _, err := awql.NewParser(strings.NewReader(" ")).ParseNext(); err != io.EOF {
+		t.Errorf("Expected the end of an empty input, received %v", err)
+	}
+}
+
+func TestParser_Reset(t *testing.T) {
+	p := awql.NewParser(strings.NewReader("SELECT Cost FROM REPORT LIMIT 5 DESC"))
+	if _, err := p.ParseSelect(); err == nil {
+		t.Fatal("Expected an error with the first query")
+	}
+	var tests = []struct {
+		q, s string
+	}{
+		{q: "DESC CAMPAIGN_PERFORMANCE_REPORT", s: "DESC CAMPAIGN_PERFORMANCE_REPORT"},
+		{q: "SELECT Cost FROM REPORT; SHOW TABLES", s: "SELECT Cost FROM REPORT"},
+		{q: "SHOW TABLES", s: "SHOW TABLES"},
+	}
+	for i, tt := range tests {
+		p.Reset(strings.NewReader(tt.q))
+		stmt, err := p.ParseRow()
+		if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.q, err)
+			continue
+		}
+		if s := stmt.String(); s != tt.s {
+			t.Errorf("%d. Expected %q, received %q", i, tt.s, s)
+		}
+	}
+}
+
+func BenchmarkParser_New(b *testing.B) {
+	q := "SELECT CampaignName, Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Cost > 0 LIMIT 5"
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := awql.NewParser(strings.NewReader(q)).ParseSelect(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParser_Reset(b *testing.B) {
+	q := "SELECT CampaignName, Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Cost > 0 LIMIT 5"
+	p := awql.NewParser(nil)
+	r := strings.NewReader(q)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r.Reset(q)
+		p.Reset(r)
+		if _, err := p.ParseSelect(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestParser_ParseFunctionPosition(t *testing.T) {
+	stmt, err := awql.ParseSelectString("SELECT Cost, SUM(1) AS total FROM CAMPAIGN_PERFORMANCE_REPORT")
+	if err != nil {
+		t.Fatalf("Expected no error with a backward position, received %v", err)
+	}
+	field := stmt.Columns()[1]
+	if method, _, ok := field.Aggregate(); !ok || method != "SUM" || field.Name() != "Cost" {
+		t.Errorf("Expected the sum of Cost, received %v(%v)", method, field.Name())
+	}
+	_, err = awql.ParseSelectString("SELECT SUM(2), Cost FROM CAMPAIGN_PERFORMANCE_REPORT")
+	if err == nil || err.Error() != "ParserError.POSITION_NOT_IN_PREVIOUS_COLUMNS (2)" {
+		t.Errorf("Expected an error with a forward position, received %v", err)
+	}
+}
+
+func TestParser_ParseDistinct(t *testing.T) {
+	var tests = []struct {
+		q        string
+		distinct bool
+		fields   []bool
+	}{
+		{q: "SELECT DISTINCT CampaignId, CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT", distinct: true, fields: []bool{false, false}},
+		{q: "SELECT CampaignId, SUM(DISTINCT Cost) FROM CAMPAIGN_PERFORMANCE_REPORT", fields: []bool{false, true}},
+		{q: "SELECT DISTINCT CampaignId, COUNT(DISTINCT AdGroupId) FROM ADGROUP_PERFORMANCE_REPORT", distinct: true, fields: []bool{false, true}},
+	}
+	for i, tt := range tests {
+		stmt, err := awql.ParseSelectString(tt.q)
+		if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.q, err)
+			continue
+		}
+		if stmt.Distinct() != tt.distinct {
+			t.Errorf("%d. Expected distinct rows to be %v with %q", i, tt.distinct, tt.q)
+		}
+		for j, f := range stmt.Columns() {
+			if _, distinct, _ := f.Aggregate(); distinct != tt.fields[j] {
+				t.Errorf("%d. Expected distinct field %d to be %v with %q", i, j, tt.fields[j], tt.q)
+			}
+		}
+		if s := stmt.String(); s != tt.q {
+			t.Errorf("%d. Expected %q, received %q", i, tt.q, s)
+		}
+	}
+}
+
+func TestParser_ParseExcept(t *testing.T) {
+	q := "SELECT * EXCEPT (Cost, `group`) FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0"
+	stmt, err := awql.ParseSelectString(strings.Replace(q, ", ", ",", 1))
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	if s := stmt.String(); s != q {
+		t.Errorf("Expected %q, received %q", q, s)
+	}
+	list := stmt.ExcludedList()
+	if len(list) != 2 || list[0].Name() != "Cost" || list[1].Name() != "group" {
+		t.Errorf("Expected Cost and group as excluded columns, received %v", list)
+	}
+	// The excluded columns are kept by the copy and the binary encoding.
+	c := stmt.(*awql.SelectStatement).Clone()
+	if !c.Equal(stmt) {
+		t.Errorf("Expected a copy equal to %q, received %q", q, c)
+	}
+	data, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Expected no error while encoding, received %v", err)
+	}
+	var res awql.SelectStatement
+	if err := res.UnmarshalBinary(data); err != nil || res.String() != q {
+		t.Errorf("Expected %q after decoding, received %q (%v)", q, res.String(), err)
+	}
+	c.Excluded = c.Excluded[:1]
+	if c.Equal(stmt) {
+		t.Error("Expected a difference with less excluded columns")
+	}
+}
+
+func TestParser_ParseLargeNumber(t *testing.T) {
+	q := "SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT " +
+		"WHERE CampaignId IN [ 9007199254740993 , 99999999999999999999 ] LIMIT 9223372036854775807"
+	stmt, err := awql.NewParser(strings.NewReader(q)).ParseSelect()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	if s := stmt.String(); s != q {
+		t.Errorf("Expected %q, received %q", q, s)
+	}
+}
+
+func TestParser_ParseQuotedIdentifier(t *testing.T) {
+	var tests = []struct {
+		q, s string
+	}{
+		{
+			q: "SELECT `Cost` AS `my cost` FROM `CAMPAIGN_PERFORMANCE_REPORT`",
+			s: "SELECT Cost AS `my cost` FROM CAMPAIGN_PERFORMANCE_REPORT",
+		},
+		{
+			q: "SELECT `group`, SUM(`a``b`) FROM `order` WHERE `group` > 0 GROUP BY `group` ORDER BY 2",
+			s: "SELECT `group`, SUM(`a``b`) FROM `order` WHERE `group` > 0 GROUP BY 1 ORDER BY 2",
+		},
+		{
+			q: "CREATE VIEW `ORDER` (`day`, `Desc`) AS SELECT Date, Cost FROM CAMPAIGN_PERFORMANCE_REPORT",
+			s: "CREATE VIEW `ORDER` (day, `Desc`) AS SELECT Date, Cost FROM CAMPAIGN_PERFORMANCE_REPORT",
+		},
+		{q: "DESC `my report` `my column`"},
+	}
+	for i, tt := range tests {
+		stmt, err := awql.NewParser(strings.NewReader(tt.q)).ParseRow()
+		if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.q, err)
+			continue
+		}
+		if tt.s == "" {
+			tt.s = tt.q
+		}
+		if s := stmt.String(); s != tt.s {
+			t.Errorf("%d. Expected %q, received %q", i, tt.s, s)
+		}
+		// The output must be parsed as the same statement.
+		if rs, err := awql.NewParser(strings.NewReader(tt.s)).ParseRow(); err != nil || rs.String() != tt.s {
+			t.Errorf("%d. Expected a round trip with %q, received %v", i, tt.s, err)
+		}
+	}
+}
+
+func TestParser_ParseLookahead(t *testing.T) {
+	var tests = []struct {
+		q, s string
+	}{
+		{q: "SELECT Cost AS, Clicks FROM R", s: "SELECT Cost AS `AS`, Clicks FROM R"},
+		{q: "SELECT Cost AS FROM R", s: "SELECT Cost AS `AS` FROM R"},
+		{q: "SELECT Cost AS c FROM R", s: "SELECT Cost AS c FROM R"},
+		{q: "SELECT SUM (Cost) FROM R", s: "SELECT SUM(Cost) FROM R"},
+	}
+	for i, tt := range tests {
+		stmt, err := awql.ParseSelectString(tt.q)
+		if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		} else if s := stmt.String(); s != tt.s {
+			t.Errorf("%d. Expected %q, received %q", i, tt.s, s)
+		}
+	}
+}
+
+func TestParser_ParseAliasReference(t *testing.T) {
+	var tests = []struct {
+		q, s string
+		err  bool
+	}{
+		{
+			q: "SELECT Cost AS Total FROM R ORDER BY total",
+			s: "SELECT Cost AS Total FROM R ORDER BY 1",
+		},
+		{
+			q: "SELECT CampaignName AS Name, SUM(Cost) AS total FROM R GROUP BY NAME ORDER BY Total DESC",
+			s: "SELECT CampaignName AS Name, SUM(Cost) AS total FROM R GROUP BY 1 ORDER BY 2 DESC",
+		},
+		{
+			q: "SELECT CampaignId AS c, Clicks AS C FROM R ORDER BY C",
+			s: "SELECT CampaignId AS c, Clicks AS C FROM R ORDER BY 2",
+		},
+		{q: "SELECT Cost FROM R ORDER BY cost", err: true},
+	}
+	for i, tt := range tests {
+		stmt, err := awql.ParseSelectString(tt.q)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%d. Expected an error with %q", i, tt.q)
+			}
+		} else if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		} else if s := stmt.String(); s != tt.s {
+			t.Errorf("%d. Expected %q, received %q", i, tt.s, s)
+		}
+	}
+}
+
+func TestParser_ParseSortOrder(t *testing.T) {
+	var tests = []struct {
+		q, s string
+		err  string
+	}{
+		{
+			q: "SELECT CampaignName, Cost FROM R ORDER BY Cost DESC, CampaignName ASC",
+			s: "SELECT CampaignName, Cost FROM R ORDER BY 2 DESC, 1 ASC",
+		},
+		{
+			q: "SELECT CampaignName, Cost FROM R ORDER BY 1, 2 LIMIT 5",
+			s: "SELECT CampaignName, Cost FROM R ORDER BY 1, 2 LIMIT 5",
+		},
+		{
+			q:   "SELECT CampaignName, Cost FROM R ORDER BY Cost DES LIMIT 5",
+			err: "ParserError.INVALID_ORDER_BY (DES)",
+		},
+		{
+			q:   "SELECT CampaignName, Cost FROM R ORDER BY Cost CampaignName",
+			err: "ParserError.INVALID_ORDER_BY (CampaignName)",
+		},
+		{
+			q:   "SELECT CampaignName, Cost FROM R ORDER BY Cost, 2 DESC",
+			err: "ParserError.DUPLICATE_ORDER_BY (2)",
+		},
+		{
+			q:   "SELECT CampaignName, Cost AS c FROM R ORDER BY c, Cost",
+			err: "ParserError.DUPLICATE_ORDER_BY (Cost)",
+		},
+	}
+	for i, tt := range tests {
+		stmt, err := awql.ParseSelectString(tt.q)
+		if tt.err != "" {
+			if err == nil || !strings.HasPrefix(err.Error(), tt.err) {
+				t.Errorf("%d. Expected the error %q with %q, received %v", i, tt.err, tt.q, err)
+			}
+		} else if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		} else if s := stmt.String(); s != tt.s {
+			t.Errorf("%d. Expected %q, received %q", i, tt.s, s)
+		}
+	}
+}
+
+func TestParser_ParseOrderIndex(t *testing.T) {
+	q := "SELECT CampaignName, Clicks, Cost AS c FROM R ORDER BY c DESC, 1, Clicks"
+	stmt, err := awql.ParseSelectString(q)
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	for i, pos := range []int{3, 1, 2} {
+		o := stmt.OrderList()[i]
+		if o.OrderIndex() != i || o.Position() != pos {
+			t.Errorf("%d. Expected the rank %d at position %d, received %d at %d", i, i, pos, o.OrderIndex(), o.Position())
+		}
+	}
+}
+
+func TestParser_ParseGroupBy(t *testing.T) {
+	var tests = []struct {
+		q, s string
+		err  string
+	}{
+		{
+			q: "SELECT CampaignName AS campaign, Device, Clicks, SUM(Cost) FROM R GROUP BY 3, campaign, Device",
+			s: "SELECT CampaignName AS campaign, Device, Clicks, SUM(Cost) FROM R GROUP BY 3, 1, 2",
+		},
+		{
+			q:   "SELECT CampaignName AS campaign, Device FROM R GROUP BY campaign, 3",
+			err: "ParserError.INVALID_GROUP_BY (3): UNKNOWN_COLUMN",
+		},
+		{
+			q:   "SELECT CampaignName AS campaign, Device FROM R GROUP BY 0, campaign",
+			err: "ParserError.INVALID_GROUP_BY (0): UNKNOWN_COLUMN",
+		},
+		{
+			q:   "SELECT CampaignName AS campaign, Device FROM R GROUP BY Device, Campaigns",
+			err: "ParserError.INVALID_GROUP_BY (Campaigns): UNKNOWN_COLUMN",
+		},
+	}
+	for i, tt := range tests {
+		stmt, err := awql.ParseSelectString(tt.q)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("%d. Expected the error %q with %q, received %v", i, tt.err, tt.q, err)
+			}
+		} else if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		} else if s := stmt.String(); s != tt.s {
+			t.Errorf("%d. Expected %q, received %q", i, tt.s, s)
+		}
+	}
+}
+
+func TestParser_ParseRollup(t *testing.T) {
+	var tests = []struct {
+		q, s   string
+		rollup bool
+		err    string
+	}{
+		{
+			q:      "SELECT CampaignName, AdGroupName, SUM(Cost) FROM R GROUP BY 1, AdGroupName with rollup ORDER BY 3 DESC",
+			s:      "SELECT CampaignName, AdGroupName, SUM(Cost) FROM R GROUP BY 1, 2 WITH ROLLUP ORDER BY 3 DESC",
+			rollup: true,
+		},
+		{
+			q: "SELECT CampaignName, SUM(Cost) FROM R GROUP BY 1",
+			s: "SELECT CampaignName, SUM(Cost) FROM R GROUP BY 1",
+		},
+		{
+			q: "SELECT Rollup FROM R",
+			s: "SELECT Rollup FROM R",
+		},
+		{
+			q:      "SELECT Rollup, SUM(Cost) AS rollup2 FROM R GROUP BY Rollup WITH ROLLUP",
+			s:      "SELECT Rollup, SUM(Cost) AS rollup2 FROM R GROUP BY 1 WITH ROLLUP",
+			rollup: true,
+		},
+		{
+			q:   "SELECT CampaignName, SUM(Cost) FROM R WITH ROLLUP",
+			err: "ParserError.ROLLUP_WITHOUT_GROUP_BY (WITH ROLLUP)",
+		},
+		{
+			q:   "SELECT CampaignName, SUM(Cost) FROM R GROUP BY 1 ORDER BY 2 WITH ROLLUP",
+			err: "ParserError.INVALID_CLAUSE_ORDER (WITH ROLLUP after ORDER BY)",
+		},
+		{
+			q:   "SELECT CampaignName, SUM(Cost) FROM R GROUP BY 1 WITH CUBE",
+			err: "ParserError.INVALID_GROUP_BY (WITH CUBE)",
+		},
+	}
+	for i, tt := range tests {
+		stmt, err := awql.ParseSelectString(tt.q)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("%d. Expected the error %q with %q, received %v", i, tt.err, tt.q, err)
+			}
+		} else if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		} else if s := stmt.String(); s != tt.s {
+			t.Errorf("%d. Expected %q, received %q", i, tt.s, s)
+		} else if stmt.WithRollup() != tt.rollup {
+			t.Errorf("%d. Expected the rollup %v with %q", i, tt.rollup, tt.q)
+		}
+	}
+}
+
+func TestParser_ParseValueList(t *testing.T) {
+	var tests = []struct {
+		q   string
+		val []string
+		lit bool
+		err bool
+		msg string
+		col int
+	}{
+		{q: `["a\"b", 'c\'d', "e\\f"]`, val: []string{`a"b`, `c'd`, `e\f`}},
+		{q: `["", '', "g"]`, val: []string{"", "", "g"}},
+		{q: `['h"i', "j'k"]`, val: []string{`h"i`, `j'k`}},
+		{q: `[ENABLED, 1.5, 2]`, val: []string{"ENABLED", "1.5", "2"}, lit: true},
+		{q: `["a" "b"]`, err: true, msg: `ParserError.MISSING_COMMA_BEFORE ('b') at line 1`, col: 47},
+		{q: `["a",, "b"]`, err: true},
+		{q: `["a",]`, val: []string{"a"}},
+		{q: `[1, 2, 3, ]`, val: []string{"1", "2", "3"}, lit: true},
+		{q: `["a",,]`, err: true},
+		{q: `[1 2]`, err: true, msg: "ParserError.MISSING_COMMA_BEFORE (2) at line 1", col: 45},
+		{q: `[,]`, err: true, msg: "ParserError.LEADING_COMMA ([,) at line 1", col: 43},
+		{q: `[, 1]`, err: true, msg: "ParserError.LEADING_COMMA ([,) at line 1", col: 43},
+		{q: `[, "a"]`, err: true},
+		{q: `[1, "a"]`, err: true},
+	}
+	for i, tt := range tests {
+		q := "SELECT Cost FROM R WHERE CampaignName IN " + tt.q
+		stmt, err := awql.ParseSelectString(q)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%d. Expected an error with %q", i, q)
+			} else if tt.msg != "" && err.Error() != tt.msg {
+				t.Errorf("%d. Expected the error %q with %q, received %q", i, tt.msg, q, err)
+			} else if pos, _ := err.(*awql.ParserError).Position(); tt.col > 0 && pos.Column != tt.col {
+				t.Errorf("%d. Expected the error at column %d with %q, received %d", i, tt.col, q, pos.Column)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, q, err)
+			continue
+		}
+		if val, lit := stmt.ConditionList()[0].Value(); !reflect.DeepEqual(val, tt.val) || lit != tt.lit {
+			t.Errorf("%d. Expected %q (%v), received %q (%v)", i, tt.val, tt.lit, val, lit)
+		}
+		// The output must be parsed with the same values.
+		rs, err := awql.ParseSelectString(stmt.String())
+		if err != nil {
+			t.Errorf("%d. Expected a round trip with %q, received %v", i, stmt.String(), err)
+		} else if val, _ := rs.ConditionList()[0].Value(); !reflect.DeepEqual(val, tt.val) {
+			t.Errorf("%d. Expected %q after a round trip, received %q", i, tt.val, val)
+		}
+	}
+}
+
+func TestParser_ParseUnknownStatement(t *testing.T) {
+	const q = "SELECT Cost FROM R;\nDESC R;\n\n  MERGE R"
+	_, err := awql.ParseString(q)
+	if exp := "ParserError.UNKNOWN_STATEMENT (MERGE) at line 4 of statement 3"; err == nil || err.Error() != exp {
+		t.Fatalf("Expected the error %q, received %v", exp, err)
+	}
+	if pos, ok := err.(*awql.ParserError).Position(); !ok || pos.Line != 4 || pos.Column != 3 {
+		t.Errorf("Expected the position 4:3, received %v", pos)
+	}
+	if awql.ErrMsgBadStmt != awql.ErrMsgUnknownStmt {
+		t.Errorf("Expected the deprecated message to be kept, received %q", awql.ErrMsgBadStmt)
+	}
+}
+
+func TestParser_ParseWriteStatement(t *testing.T) {
+	var tests = []struct {
+		q, verb string
+	}{
+		{q: `UPDATE R SET Cost = 1`, verb: "UPDATE"},
+		{q: `delete FROM R`, verb: "DELETE"},
+		{q: `INSERT INTO R VALUES (1)`, verb: "INSERT"},
+		{q: `TRUNCATE R`, verb: "TRUNCATE"},
+		{q: `ALTER TABLE R ADD Cost`, verb: "ALTER TABLE"},
+		{q: `Alter R`, verb: "ALTER"},
+	}
+	for i, tt := range tests {
+		_, err := awql.ParseString(tt.q)
+		exp := "ParserError.UNSUPPORTED_STATEMENT (" + tt.verb + ") at line 1 of statement 1: AWQL is read-only"
+		if err == nil || err.Error() != exp {
+			t.Errorf("%d. Expected the error %q with %q, received %v", i, exp, tt.q, err)
+		}
+	}
+	// The verbs are not reserved words.
+	if _, err := awql.ParseString(`SELECT Update, Delete FROM R`); err != nil {
+		t.Errorf("Expected no error with columns named as SQL verbs, received %v", err)
+	}
+}
+
+func TestParseSelects(t *testing.T) {
+	const q = `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT; SELECT AdGroupName FROM ADGROUP_PERFORMANCE_REPORT;`
+	stmts, err := awql.ParseSelects(strings.NewReader(q))
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	if len(stmts) != 2 || stmts[1].SourceName() != "ADGROUP_PERFORMANCE_REPORT" {
+		t.Errorf("Expected 2 select statements, received %v", stmts)
+	}
+
+	var tests = []struct {
+		q   string
+		err error
+	}{
+		{
+			q:   `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT; SHOW TABLES; DESC CAMPAIGN_PERFORMANCE_REPORT;`,
+			err: awql.NewXParserError(awql.ErrMsgUnexpectedStmt, "statement 2 is a SHOW, expected SELECT"),
+		},
+		{
+			q:   `SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT; SELECT`,
+			err: awql.NewXParserError(awql.ErrMsgBadField, ""),
+		},
+	}
+	for i, tt := range tests {
+		if _, err := awql.ParseSelects(strings.NewReader(tt.q)); err == nil || err.Error() != tt.err.Error() {
+			t.Errorf("%d. Expected the error %v with %q, received %v", i, tt.err, tt.q, err)
+		}
+	}
+}
+
+func TestParseKinds(t *testing.T) {
+	if list, err := awql.ParseDescribes(strings.NewReader(`DESC A; DESC FULL B`)); err != nil || len(list) != 2 {
+		t.Errorf("Expected 2 describe statements, received %v, %v", list, err)
+	}
+	if list, err := awql.ParseCreateViews(strings.NewReader(`CREATE VIEW V AS SELECT A FROM B`)); err != nil || len(list) != 1 {
+		t.Errorf("Expected 1 create view statement, received %v, %v", list, err)
+	}
+	if list, err := awql.ParseShows(strings.NewReader(`SHOW TABLES; SHOW FULL TABLES`)); err != nil || len(list) != 2 {
+		t.Errorf("Expected 2 show statements, received %v, %v", list, err)
+	}
+	if list, err := awql.ParseUses(strings.NewReader(`USE 123-456-7890`)); err != nil || len(list) != 1 {
+		t.Errorf("Expected 1 use statement, received %v, %v", list, err)
+	}
+	const exp = "ParserError.UNEXPECTED_STATEMENT (statement 1 is a USE, expected DESCRIBE)"
+	if _, err := awql.ParseDescribes(strings.NewReader(`USE 123-456-7890`)); err == nil || err.Error() != exp {
+		t.Errorf("Expected the error %v, received %v", exp, err)
+	}
+}
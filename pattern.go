@@ -0,0 +1,110 @@
+package awqlparse
+
+import "strings"
+
+// Like with %
+const wildcard = "%"
+
+// newPattern returns the Pattern matching the LIKE expression.
+// Leading or trailing wildcards are translated into prefix, suffix or contains search.
+// If the expression uses wildcards elsewhere, it is kept as is, to be split in segments.
+func newPattern(expr string) Pattern {
+	p := Pattern{}
+	if strings.Contains(strings.Trim(expr, wildcard), wildcard) {
+		// Wildcard in the middle of the pattern.
+		p.Expr = expr
+		return p
+	}
+	wl := strings.HasPrefix(expr, wildcard)
+	wr := strings.HasSuffix(expr, wildcard)
+	switch {
+	case wl && wr:
+		p.Contains = strings.Trim(expr, wildcard)
+	case wl:
+		p.Suffix = strings.TrimLeft(expr, wildcard)
+	case wr:
+		p.Prefix = strings.TrimRight(expr, wildcard)
+	default:
+		p.Equal = expr
+	}
+	return p
+}
+
+// Segments returns the texts between the wildcards of the expression,
+// with an empty one at the edges if it starts or ends with a wildcard.
+// It returns nil if the pattern has no wildcard inside it.
+func (p Pattern) Segments() []string {
+	if p.Expr == "" {
+		return nil
+	}
+	return strings.Split(p.Expr, wildcard)
+}
+
+// String returns the LIKE expression of the pattern.
+func (p Pattern) String() string {
+	switch {
+	case p.Expr != "":
+		return p.Expr
+	case p.Equal != "":
+		return p.Equal
+	case p.Contains != "":
+		return wildcard + p.Contains + wildcard
+	case p.Prefix != "":
+		return p.Prefix + wildcard
+	case p.Suffix != "":
+		return wildcard + p.Suffix
+	}
+	return ""
+}
+
+// Match returns true if the string matches the pattern.
+// An empty pattern matches everything.
+func (p Pattern) Match(s string) bool {
+	switch {
+	case p.Expr != "":
+		return matchSegments(s, p.Segments())
+	case p.Equal != "":
+		return s == p.Equal
+	case p.Contains != "":
+		return strings.Contains(s, p.Contains)
+	case p.Prefix != "":
+		return strings.HasPrefix(s, p.Prefix)
+	case p.Suffix != "":
+		return strings.HasSuffix(s, p.Suffix)
+	}
+	return true
+}
+
+// MatchFold is the case-insensitive version of Match.
+func (p Pattern) MatchFold(s string) bool {
+	up := Pattern{
+		Equal:    strings.ToUpper(p.Equal),
+		Prefix:   strings.ToUpper(p.Prefix),
+		Contains: strings.ToUpper(p.Contains),
+		Suffix:   strings.ToUpper(p.Suffix),
+		Expr:     strings.ToUpper(p.Expr),
+	}
+	return up.Match(strings.ToUpper(s))
+}
+
+// matchSegments returns true if the string starts with the first segment,
+// ends with the last one and contains the others in this order between them.
+// Without wildcard between them, a single segment must be equal to the string.
+func matchSegments(s string, segments []string) bool {
+	if len(segments) < 2 {
+		return s == strings.Join(segments, "")
+	}
+	last := len(segments) - 1
+	if !strings.HasPrefix(s, segments[0]) {
+		return false
+	}
+	s = s[len(segments[0]):]
+	for _, seg := range segments[1:last] {
+		pos := strings.Index(s, seg)
+		if pos < 0 {
+			return false
+		}
+		s = s[pos+len(seg):]
+	}
+	return strings.HasSuffix(s, segments[last])
+}
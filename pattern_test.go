@@ -0,0 +1,72 @@
+package awqlparse_test
+
+import (
+	"reflect"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestPattern_Match(t *testing.T) {
+	var tests = []struct {
+		p        awql.Pattern
+		s        string
+		ok, fold bool
+	}{
+		// Empty pattern.
+		{p: awql.Pattern{}, s: "CAMPAIGN_PERFORMANCE_REPORT", ok: true, fold: true},
+		{p: awql.Pattern{}, s: "", ok: true, fold: true},
+		// Equal.
+		{p: awql.Pattern{Equal: "LABEL"}, s: "LABEL", ok: true, fold: true},
+		{p: awql.Pattern{Equal: "LABEL"}, s: "label", fold: true},
+		{p: awql.Pattern{Equal: "LABEL"}, s: "LABELS"},
+		// Prefix.
+		{p: awql.Pattern{Prefix: "CAMPAIGN"}, s: "CAMPAIGN_PERFORMANCE_REPORT", ok: true, fold: true},
+		{p: awql.Pattern{Prefix: "CAMPAIGN"}, s: "campaign_performance_report", fold: true},
+		{p: awql.Pattern{Prefix: "CAMPAIGN"}, s: "ADGROUP_PERFORMANCE_REPORT"},
+		// Suffix.
+		{p: awql.Pattern{Suffix: "REPORT"}, s: "CAMPAIGN_PERFORMANCE_REPORT", ok: true, fold: true},
+		{p: awql.Pattern{Suffix: "REPORT"}, s: "LABEL"},
+		// Contains.
+		{p: awql.Pattern{Contains: "NEGATIVE"}, s: "CAMPAIGN_NEGATIVE_KEYWORDS_PERFORMANCE_REPORT", ok: true, fold: true},
+		{p: awql.Pattern{Contains: "NEGATIVE"}, s: "CAMPAIGN_Negative_KEYWORDS", fold: true},
+		{p: awql.Pattern{Contains: "NEGATIVE"}, s: "CAMPAIGN_PERFORMANCE_REPORT"},
+		// Segments.
+		{p: awql.Pattern{Expr: "CAMPAIGN%REPORT"}, s: "CAMPAIGN_PERFORMANCE_REPORT", ok: true, fold: true},
+		{p: awql.Pattern{Expr: "CAMPAIGN%REPORT"}, s: "CAMPAIGNREPORT", ok: true, fold: true},
+		{p: awql.Pattern{Expr: "CAMPAIGN%REPORT"}, s: "CAMPAIGN_REPORT_LABEL"},
+		{p: awql.Pattern{Expr: "%AD%PERF%"}, s: "ADGROUP_PERFORMANCE_REPORT", ok: true, fold: true},
+		{p: awql.Pattern{Expr: "%PERF%AD%"}, s: "ADGROUP_PERFORMANCE_REPORT"},
+		{p: awql.Pattern{Expr: "AB%BA"}, s: "ABA"},
+		{p: awql.Pattern{Expr: "ADGROUP"}, s: "ADGROUP", ok: true, fold: true},
+		{p: awql.Pattern{Expr: "ADGROUP"}, s: "ADGROUP_PERFORMANCE_REPORT"},
+	}
+
+	for i, tt := range tests {
+		if ok := tt.p.Match(tt.s); ok != tt.ok {
+			t.Errorf("%d. Expected %v with %q on %#v, received %v", i, tt.ok, tt.s, tt.p, ok)
+		}
+		if ok := tt.p.MatchFold(tt.s); ok != tt.fold {
+			t.Errorf("%d. Expected %v with %q on %#v (case-insensitive), received %v", i, tt.fold, tt.s, tt.p, ok)
+		}
+	}
+}
+
+func TestPattern_Segments(t *testing.T) {
+	stmt, err := awql.ParseShowString(`SHOW TABLES LIKE '%AD%PERFORMANCE%'`)
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	p, _ := stmt.LikePattern()
+	if exp := []string{"", "AD", "PERFORMANCE", ""}; !reflect.DeepEqual(p.Segments(), exp) {
+		t.Errorf("Expected the segments %q, received %q", exp, p.Segments())
+	}
+	// The pattern stays comparable, to be used as map key.
+	seen := map[awql.Pattern]bool{p: true}
+	if !seen[awql.Pattern{Expr: "%AD%PERFORMANCE%"}] || p == (awql.Pattern{Prefix: "AD"}) {
+		t.Errorf("Expected the pattern %#v comparable by value", p)
+	}
+	if s := (awql.Pattern{Prefix: "AD"}).Segments(); s != nil {
+		t.Errorf("Expected no segment without wildcard inside, received %q", s)
+	}
+}
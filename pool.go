@@ -0,0 +1,56 @@
+package awqlparse
+
+import (
+	"strings"
+	"sync"
+)
+
+// pooledParser is a parser reused between the calls of the package-level parse functions, with its reader.
+type pooledParser struct {
+	p *Parser
+	r strings.Reader
+}
+
+// parserPool keeps the parsers with their default options.
+// A pooled parser keeps its scanner with the buffer used to read the literals of the tokens,
+// so only the statement and its literals are allocated by a parse.
+var parserPool = sync.Pool{
+	New: func() interface{} {
+		pp := &pooledParser{}
+		pp.p = NewParser(&pp.r)
+		return pp
+	},
+}
+
+// getParser returns a parser of the pool, ready to read the query.
+func getParser(q string) *pooledParser {
+	pp := parserPool.Get().(*pooledParser)
+	pp.r.Reset(q)
+	pp.p.Reset(&pp.r)
+	return pp
+}
+
+// release puts back the parser in the pool, without reference to its last query.
+func (pp *pooledParser) release() {
+	pp.r.Reset("")
+	pp.p.Reset(&pp.r)
+	parserPool.Put(pp)
+}
+
+// ParseQuery parses the AWQL statements of the query, as ParseString,
+// but reuses the parsers, their scanners and the buffer of these ones between the calls.
+// It is safe for concurrent use.
+func ParseQuery(q string) ([]Stmt, error) {
+	pp := getParser(q)
+	defer pp.release()
+	return pp.p.Parse()
+}
+
+// ParseSelectQuery parses the query as a AWQL SELECT statement, as ParseSelectString,
+// but reuses the parsers, their scanners and the buffer of these ones between the calls.
+// It is safe for concurrent use.
+func ParseSelectQuery(q string) (SelectStmt, error) {
+	pp := getParser(q)
+	defer pp.release()
+	return pp.p.ParseSelect()
+}
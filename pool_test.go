@@ -0,0 +1,63 @@
+package awqlparse_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestParseSelectQuery(t *testing.T) {
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			q := fmt.Sprintf("SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId = %d", i)
+			stmt, err := awql.ParseSelectQuery(q)
+			if err != nil {
+				errs <- err
+			} else if s := stmt.String(); s != q {
+				errs <- fmt.Errorf("expected %q, received %q", q, s)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	// A parse error does not affect the next calls.
+	if _, err := awql.ParseSelectQuery("SELECT Cost FROM"); err == nil {
+		t.Error("Expected an error with an incomplete query")
+	}
+	if stmts, err := awql.ParseQuery("DESC R; SHOW TABLES"); err != nil || len(stmts) != 2 {
+		t.Errorf("Expected 2 statements, received %d (%v)", len(stmts), err)
+	}
+}
+
+func BenchmarkParseSelectString(b *testing.B) {
+	q := "SELECT CampaignName, Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Cost > 0 LIMIT 5"
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := awql.ParseSelectString(q); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkParseSelectQuery(b *testing.B) {
+	q := "SELECT CampaignName, Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Cost > 0 LIMIT 5"
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := awql.ParseSelectQuery(q); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,199 @@
+package awqlparse
+
+import "strings"
+
+// Default indentation of the pretty output.
+const defaultIndent = "  "
+
+// FormatOptions represents the settings of the pretty output.
+type FormatOptions struct {
+	// Indent is the string used to indent the items of a clause.
+	// By default, two spaces are used.
+	Indent string
+	// Lowercase outputs the reserved keywords in lowercase.
+	Lowercase bool
+}
+
+// indent returns the indentation to use.
+func (o FormatOptions) indent() string {
+	if o.Indent == "" {
+		return defaultIndent
+	}
+	return o.Indent
+}
+
+// keyword returns the keyword with the expected case.
+func (o FormatOptions) keyword(s string) string {
+	if o.Lowercase {
+		return strings.ToLower(s)
+	}
+	return s
+}
+
+// list outputs each item on its own indented line, separated by the given string.
+func (o FormatOptions) list(items []string, sep string) (q string) {
+	for i, v := range items {
+		if i > 0 {
+			q += sep
+		}
+		q += "\n" + o.indent() + v
+	}
+	return
+}
+
+// PrettyString outputs a create view statement with one clause per line.
+func (s CreateViewStatement) PrettyString(opts FormatOptions) (q string) {
+	if s.SourceName() == "" {
+		return
+	}
+	q = opts.keyword("CREATE ")
+	if s.ReplaceMode() {
+		q += opts.keyword("OR REPLACE ")
+	}
+	q += opts.keyword("VIEW ") + quoteName(s.SourceName())
+
+	// Lists field names.
+	if cols := s.Columns(); len(cols) > 0 {
+		names := make([]string, len(cols))
+		for i, c := range cols {
+			names[i] = quoteName(c.Name())
+		}
+		q += " (" + opts.list(names, ",") + "\n)"
+	}
+
+	// Adds the data source.
+	v := s.View.PrettyString(opts)
+	if v == "" {
+		return ""
+	}
+	q += "\n" + opts.keyword("AS") + "\n" + v
+	if !s.View.VerticalOutput() {
+		q += s.modifierString()
+	}
+
+	return
+}
+
+// PrettyString outputs a describe statement.
+// The statement is short, so it uses only one line, only the keywords case changes.
+func (s DescribeStatement) PrettyString(opts FormatOptions) (q string) {
+	if s.SourceName() == "" {
+		return
+	}
+	q = opts.keyword("DESC ")
+	if s.FullMode() {
+		q += opts.keyword("FULL ")
+	}
+	q += quoteName(s.SourceName())
+
+	if cols := s.Columns(); len(cols) == 1 {
+		q += " " + quoteName(cols[0].Name())
+	}
+	q += s.modifierString()
+
+	return
+}
+
+// PrettyString outputs a select statement with one clause per line,
+// each field and condition on its own indented line.
+func (s SelectStatement) PrettyString(opts FormatOptions) (q string) {
+	if len(s.Columns()) == 0 || s.SourceName() == "" {
+		return
+	}
+
+	// Adds columns.
+	cols := s.Columns()
+	fields := make([]string, len(cols))
+	for i, c := range cols {
+		fields[i] = opts.field(c)
+	}
+	if s.Distinct() {
+		q = opts.keyword("SELECT DISTINCT") + opts.list(fields, ",")
+	} else {
+		q = opts.keyword("SELECT") + opts.list(fields, ",")
+	}
+	if e := s.exceptString(); e != "" {
+		q += "\n" + opts.keyword("EXCEPT ") + "(" + e + ")"
+	}
+
+	// Adds data source name.
+	q += "\n" + opts.keyword("FROM ") + quoteName(s.SourceName())
+
+	// Adds conditions.
+	if conds := s.ConditionList(); len(conds) > 0 {
+		where := make([]string, len(conds))
+		for i, c := range conds {
+			where[i] = conditionString(c)
+			if i > 0 {
+				where[i] = opts.keyword("AND ") + where[i]
+			}
+		}
+		q += "\n" + opts.keyword("WHERE") + opts.list(where, "")
+	}
+
+	// Adds the other clauses.
+	if d := s.duringValue(); d != "" {
+		q += "\n" + opts.keyword("DURING ") + d
+	}
+	if g := s.groupString(); g != "" {
+		q += "\n" + opts.keyword("GROUP BY ") + strings.Replace(g, " WITH ROLLUP", opts.keyword(" WITH ROLLUP"), 1)
+	}
+	if o := s.orderString(); o != "" {
+		q += "\n" + opts.keyword("ORDER BY ") + opts.sortOrder(o)
+	}
+	if l := s.limitString(); l != "" {
+		q += "\n" + opts.keyword("LIMIT ") + l
+	}
+	q += s.modifierString()
+
+	return
+}
+
+// field outputs a selected field with keywords in the expected case.
+func (o FormatOptions) field(c DynamicField) (q string) {
+	method, distinct, ok := c.Aggregate()
+	if distinct {
+		q = o.keyword("DISTINCT ")
+	}
+	q += quoteName(c.Name())
+	if ok {
+		q = method + "(" + q + ")"
+	}
+	if alias, ok := c.Alias(); ok {
+		q += o.keyword(" AS ") + quoteName(alias)
+	}
+	return
+}
+
+// sortOrder changes the case of the DESC and ASC keywords of the order by clause.
+func (o FormatOptions) sortOrder(s string) string {
+	s = strings.Replace(s, " DESC", o.keyword(" DESC"), -1)
+	return strings.Replace(s, " ASC", o.keyword(" ASC"), -1)
+}
+
+// PrettyString outputs a show statement with one clause per line.
+func (s ShowStatement) PrettyString(opts FormatOptions) (q string) {
+	q = opts.keyword("SHOW ")
+	if s.FullMode() {
+		q += opts.keyword("FULL ")
+	}
+	q += opts.keyword("TABLES")
+
+	if p, used := s.likeValue(); used {
+		q += "\n" + opts.keyword("LIKE ") + p
+	}
+	if str, used := s.withValue(); used {
+		q += "\n" + opts.keyword("WITH ") + str
+	}
+	q += s.modifierString()
+
+	return
+}
+
+// PrettyString outputs a use statement.
+func (s UseStatement) PrettyString(opts FormatOptions) (q string) {
+	if q = s.String(); q != "" {
+		q = opts.keyword("USE") + strings.TrimPrefix(q, "USE")
+	}
+	return
+}
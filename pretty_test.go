@@ -0,0 +1,62 @@
+package awqlparse_test
+
+import (
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestStmt_PrettyString(t *testing.T) {
+	var tests = []struct {
+		q, pq string
+		opts  awql.FormatOptions
+	}{
+		{
+			q:  `SELECT CampaignName, SUM(Cost) AS c FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus = "ENABLED" AND Clicks > 0 DURING LAST_7_DAYS GROUP BY 1 ORDER BY 2 DESC LIMIT 5, 10`,
+			pq: "SELECT\n  CampaignName,\n  SUM(Cost) AS c\nFROM CAMPAIGN_PERFORMANCE_REPORT\nWHERE\n  CampaignStatus = 'ENABLED'\n  AND Clicks > 0\nDURING LAST_7_DAYS\nGROUP BY 1\nORDER BY 2 DESC\nLIMIT 5, 10",
+		},
+		{
+			q:    `SELECT DISTINCT Cost FROM CAMPAIGN_PERFORMANCE_REPORT ORDER BY 1 DESC\G`,
+			pq:   "select distinct\n\tCost\nfrom CAMPAIGN_PERFORMANCE_REPORT\norder by 1 desc\\G",
+			opts: awql.FormatOptions{Indent: "\t", Lowercase: true},
+		},
+		{
+			q:    `SELECT CampaignName, SUM(Cost) FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1 WITH ROLLUP`,
+			pq:   "select\n  CampaignName,\n  SUM(Cost)\nfrom CAMPAIGN_PERFORMANCE_REPORT\ngroup by 1 with rollup",
+			opts: awql.FormatOptions{Lowercase: true},
+		},
+		{
+			q:  `CREATE OR REPLACE VIEW rv (Name, Cost) AS SELECT CampaignName, Cost FROM CAMPAIGN_PERFORMANCE_REPORT DURING TODAY`,
+			pq: "CREATE OR REPLACE VIEW rv (\n  Name,\n  Cost\n)\nAS\nSELECT\n  CampaignName,\n  Cost\nFROM CAMPAIGN_PERFORMANCE_REPORT\nDURING TODAY",
+		},
+		{
+			q:    `DESC FULL CAMPAIGN_PERFORMANCE_REPORT CampaignName`,
+			pq:   "desc full CAMPAIGN_PERFORMANCE_REPORT CampaignName",
+			opts: awql.FormatOptions{Lowercase: true},
+		},
+		{
+			q:  `SHOW FULL TABLES LIKE "CAMPAIGN%"`,
+			pq: "SHOW FULL TABLES\nLIKE 'CAMPAIGN%'",
+		},
+		{
+			q:  `SHOW TABLES WITH CampaignName\G`,
+			pq: "SHOW TABLES\nWITH CampaignName\\G",
+		},
+		{
+			q:    `USE 123-456-7890`,
+			pq:   "use 123-456-7890",
+			opts: awql.FormatOptions{Lowercase: true},
+		},
+	}
+
+	for i, qt := range tests {
+		stmt, err := awql.NewParser(strings.NewReader(qt.q)).ParseRow()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with '%v', received %v", i, qt.q, err)
+		}
+		if q := stmt.PrettyString(qt.opts); q != qt.pq {
+			t.Errorf("%d. Expected the query %q with '%s', received %q", i, qt.pq, qt.q, q)
+		}
+	}
+}
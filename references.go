@@ -0,0 +1,58 @@
+package awqlparse
+
+// ReferencedColumns returns the columns used by the statement in any of its clauses,
+// including the ones of the source query of a view and the column of a SHOW...WITH statement.
+// The columns referenced by position or by alias are resolved to the field they point at.
+// Each column is listed once, by order of appearance, without alias.
+// The columns declared by a CREATE VIEW statement are names of the view and so are ignored.
+func ReferencedColumns(stmt Stmt) []Column {
+	var (
+		cols []Column
+		cur  SelectStmt
+		seen = make(map[string]bool)
+	)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			cols = append(cols, Column{ColumnName: name})
+		}
+	}
+	Walk(stmt, func(node interface{}) bool {
+		switch n := node.(type) {
+		case CreateViewStmt:
+			// Skips the declared columns.
+			if v := n.SourceQuery(); !isNilSelect(v) {
+				for _, c := range ReferencedColumns(v) {
+					add(c.ColumnName)
+				}
+			}
+			return false
+		case SelectStmt:
+			cur = n
+		case ShowStmt:
+			if name, ok := n.WithFieldName(); ok {
+				add(name)
+			}
+		case Orderer:
+			add(fieldAt(cur, n))
+		case FieldPosition:
+			add(fieldAt(cur, n))
+		case Field:
+			add(n.Name())
+		case DynamicField:
+			add(n.Name())
+		}
+		return true
+	})
+	return cols
+}
+
+// fieldAt returns the name of the field at the position, or the name of the column if it is unknown.
+func fieldAt(s SelectStmt, c FieldPosition) string {
+	if s != nil {
+		if cols := s.OutputColumns(); c.Position() > 0 && c.Position() <= len(cols) {
+			return cols[c.Position()-1].Column.Name()
+		}
+	}
+	return c.Name()
+}
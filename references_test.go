@@ -0,0 +1,58 @@
+package awqlparse_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestReferencedColumns(t *testing.T) {
+	var tests = []struct {
+		q    string
+		cols []string
+	}{
+		{
+			q:    `SELECT CampaignId, CampaignName AS n, SUM(Clicks) FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Impressions > 0 AND CampaignId > 1 GROUP BY 1, 2 ORDER BY n DESC`,
+			cols: []string{"CampaignId", "CampaignName", "Clicks", "Impressions"},
+		},
+		{
+			q:    `SELECT CampaignId, COUNT(1) AS c FROM CAMPAIGN_PERFORMANCE_REPORT ORDER BY c`,
+			cols: []string{"CampaignId"},
+		},
+		{
+			q:    `CREATE VIEW rv (id, name) AS SELECT CampaignId, CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Cost > 0`,
+			cols: []string{"CampaignId", "CampaignName", "Cost"},
+		},
+		{
+			q:    `SELECT * EXCEPT (Cost, CampaignName) FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0`,
+			cols: []string{"*", "Cost", "CampaignName", "Clicks"},
+		},
+		{
+			q:    `DESC CAMPAIGN_PERFORMANCE_REPORT CampaignId`,
+			cols: []string{"CampaignId"},
+		},
+		{
+			q:    `SHOW TABLES WITH CampaignId`,
+			cols: []string{"CampaignId"},
+		},
+		{
+			q: `SHOW TABLES LIKE "CAMPAIGN%"`,
+		},
+	}
+
+	for i, tt := range tests {
+		stmts, err := awql.NewParser(strings.NewReader(tt.q)).Parse()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		}
+		var cols []string
+		for _, c := range awql.ReferencedColumns(stmts[0]) {
+			cols = append(cols, c.Name())
+		}
+		if !reflect.DeepEqual(cols, tt.cols) {
+			t.Errorf("%d. Expected %v with %q, received %v", i, tt.cols, tt.q, cols)
+		}
+	}
+}
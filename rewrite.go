@@ -0,0 +1,105 @@
+package awqlparse
+
+// RewriteColumns renames in place the columns of the statement with the mapping,
+// everywhere they appear: fields, excluded columns, conditions, GROUP BY and ORDER BY clauses, and
+// the source query of a view. The aliases are kept. A column shared by several
+// clauses, as a field used by position in the ORDER BY clause, is renamed once.
+// It returns an error if a node can not be renamed or if a position does not
+// match anymore the field it refers to. In this case, the statement is left unchanged.
+func RewriteColumns(stmt Stmt, mapping map[string]string) (err error) {
+	// Names of the renamed columns before the rewriting, to restore them on error.
+	renamed := make(map[*Column]string)
+	defer func() {
+		if err != nil {
+			for col, name := range renamed {
+				col.ColumnName = name
+			}
+		}
+	}()
+	visited := make(map[*Column]bool)
+	Walk(stmt, func(node interface{}) bool {
+		var col *Column
+		switch n := node.(type) {
+		case Stmt, DuringValue:
+			return true
+		case *DynamicColumn:
+			col = n.Column
+		case *Column:
+			col = n
+		case *Where:
+			col = n.Column
+		case *ColumnPosition:
+			col = n.Column
+		case *Order:
+			if n.ColumnPosition != nil {
+				col = n.Column
+			}
+		}
+		if col == nil {
+			err = NewXParserError(ErrMsgBadColumn, node)
+			return false
+		}
+		if !visited[col] {
+			visited[col] = true
+			if name, ok := mapping[col.ColumnName]; ok {
+				renamed[col] = col.ColumnName
+				col.ColumnName = name
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return
+	}
+	return checkPositions(stmt)
+}
+
+// RewriteSource renames in place the source of the statement with the mapping,
+// including the one of the source query of a view.
+func RewriteSource(stmt Stmt, mapping map[string]string) (err error) {
+	Walk(stmt, func(node interface{}) bool {
+		s, ok := node.(DataStmt)
+		if !ok {
+			return true
+		}
+		name, ok := mapping[s.SourceName()]
+		if !ok {
+			return true
+		}
+		if ss, ok := s.(interface {
+			SetSourceName(string)
+		}); ok {
+			ss.SetSourceName(name)
+			return true
+		}
+		err = NewXParserError(ErrMsgBadSrc, s.SourceName())
+		return false
+	})
+	return
+}
+
+// checkPositions returns an error if a column of the GROUP BY or ORDER BY clauses
+// does not have the name of the field at its position.
+func checkPositions(stmt Stmt) (err error) {
+	check := func(s SelectStmt, c FieldPosition) bool {
+		pos := c.Position()
+		if pos < 1 || pos > len(s.Columns()) || s.Columns()[pos-1].Name() != c.Name() {
+			err = NewXParserError(ErrMsgBadColumn, pos)
+			return false
+		}
+		return true
+	}
+	var cur SelectStmt
+	Walk(stmt, func(node interface{}) bool {
+		switch n := node.(type) {
+		case SelectStmt:
+			cur = n
+		case Orderer:
+			return check(cur, n)
+		case FieldPosition:
+			return check(cur, n)
+		}
+		return true
+	})
+	return
+}
@@ -0,0 +1,115 @@
+package awqlparse_test
+
+import (
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestRewriteColumns(t *testing.T) {
+	var tests = []struct {
+		fq, tq string
+	}{
+		{
+			fq: `SELECT id, name AS n, SUM(clicks) FROM rv WHERE id > 1 AND status = "ENABLED" GROUP BY 1, 2 ORDER BY 3 DESC`,
+			tq: `SELECT CampaignId, CampaignName AS n, SUM(Clicks) FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignId > 1 AND CampaignStatus = 'ENABLED' GROUP BY 1, 2 ORDER BY 3 DESC`,
+		},
+		{
+			fq: `SELECT id, MAX(1) FROM rv ORDER BY 1`,
+			tq: `SELECT CampaignId, MAX(CampaignId) FROM CAMPAIGN_PERFORMANCE_REPORT ORDER BY 1`,
+		},
+		{
+			fq: `SELECT * EXCEPT (name, status) FROM rv`,
+			tq: `SELECT * EXCEPT (CampaignName, CampaignStatus) FROM CAMPAIGN_PERFORMANCE_REPORT`,
+		},
+		{
+			fq: `CREATE VIEW v (a) AS SELECT id FROM rv`,
+			tq: `CREATE VIEW v (a) AS SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT`,
+		},
+	}
+	columns := map[string]string{
+		"id":      "CampaignId",
+		"name":    "CampaignName",
+		"clicks":  "Clicks",
+		"status":  "CampaignStatus",
+		"Clicks":  "Never",
+		"missing": "Missing",
+	}
+	sources := map[string]string{"rv": "CAMPAIGN_PERFORMANCE_REPORT"}
+
+	for i, tt := range tests {
+		stmts, err := awql.NewParser(strings.NewReader(tt.fq)).Parse()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.fq, err)
+		}
+		if err := awql.RewriteColumns(stmts[0], columns); err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.fq, err)
+		}
+		if err := awql.RewriteSource(stmts[0], sources); err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.fq, err)
+		}
+		if q := stmts[0].String(); q != tt.tq {
+			t.Errorf("%d. Expected %q with %q, received %q", i, tt.tq, tt.fq, q)
+		}
+	}
+}
+
+func TestRewriteColumns_Positions(t *testing.T) {
+	stmt := &awql.SelectStatement{}
+	stmt.TableName = "CAMPAIGN_PERFORMANCE_REPORT"
+	stmt.Fields = []awql.DynamicField{awql.NewDynamicColumn(awql.NewColumn("id", ""), "", false)}
+	// The column of the order is not shared with the field.
+	stmt.OrderBy = []awql.Orderer{&awql.Order{ColumnPosition: awql.NewColumnPosition(awql.NewColumn("id", ""), 1)}}
+
+	if err := awql.RewriteColumns(stmt, map[string]string{"id": "CampaignId"}); err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	if name := stmt.OrderBy[0].Name(); name != "CampaignId" {
+		t.Errorf("Expected CampaignId as order, received %q", name)
+	}
+
+	// The order refers to another field than the one at its position.
+	stmt.OrderBy[0].(*awql.Order).ColumnName = "CampaignName"
+	if err := awql.RewriteColumns(stmt, nil); err == nil {
+		t.Error("Expected an error with an inconsistent position")
+	}
+}
+
+func TestRewriteColumns_Unchanged(t *testing.T) {
+	q := `SELECT id, name FROM rv WHERE id > 1 ORDER BY 2`
+	stmt, err := awql.ParseSelectString(q)
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	// The column of the order is not shared anymore with the field at its position.
+	stmt.OrderList()[0].(*awql.Order).ColumnPosition = awql.NewColumnPosition(awql.NewColumn("name", ""), 2)
+	if err := awql.RewriteColumns(stmt, map[string]string{"id": "CampaignId", "name": "CampaignName"}); err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	if s := stmt.String(); s != `SELECT CampaignId, CampaignName FROM rv WHERE CampaignId > 1 ORDER BY 2` {
+		t.Errorf("Expected the columns renamed, received %q", s)
+	}
+
+	// The field and the order are renamed differently, so they do not match anymore.
+	stmt, _ = awql.ParseSelectString(q)
+	stmt.OrderList()[0].(*awql.Order).ColumnPosition = awql.NewColumnPosition(awql.NewColumn("name", ""), 2)
+	stmt.(*awql.SelectStatement).Fields[1].(*awql.DynamicColumn).ColumnName = "label"
+	mapping := map[string]string{"id": "CampaignId", "name": "CampaignName", "label": "Labels"}
+	if err := awql.RewriteColumns(stmt, mapping); err == nil {
+		t.Fatal("Expected an error with an inconsistent position")
+	}
+	if s := stmt.String(); s != `SELECT id, label FROM rv WHERE id > 1 ORDER BY 2` {
+		t.Errorf("Expected the statement unchanged, received %q", s)
+	}
+
+	// A node without column stops the rewriting.
+	stmt, _ = awql.ParseSelectString(q)
+	stmt.(*awql.SelectStatement).Where = append(stmt.ConditionList(), &awql.Where{})
+	if err := awql.RewriteColumns(stmt, mapping); err == nil {
+		t.Fatal("Expected an error with a condition without column")
+	}
+	if name := stmt.Columns()[0].Name(); name != "id" {
+		t.Errorf("Expected the field unchanged, received %q", name)
+	}
+}
@@ -5,28 +5,92 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // eof represents a marker rune for the end of the reader.
 var eof = rune(0)
 
+// bom represents the UTF-8 byte order mark.
+const bom = '\uFEFF'
+
+// Default maximum sizes of the tokens, in bytes.
+const (
+	DefaultMaxIdentifierBytes = 1 << 10
+	DefaultMaxStringBytes     = 64 << 10
+)
+
 // Scanner represents a lexical scanner.
 type Scanner struct {
-	r *bufio.Reader
+	r    io.RuneScanner
+	buf  *bufio.Reader // buffer used with the readers unable to unread
+	pos  Pos           // position of the next rune
+	prev Pos           // position before the last read rune
+	back bool          // the last read rune can be unread
+	// Maximum sizes of the identifiers, numbers and strings, the default ones if zero.
+	maxIdent, maxString int
+	tok                 bytes.Buffer // literal of the current token, reused between the tokens
+}
+
+// Pos represents a position in the input.
+// Line and Column start at 1, the Offset in bytes at 0.
+type Pos struct {
+	Line, Column, Offset int
+}
+
+// String returns the position as line:column.
+func (p Pos) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
 }
 
 // NewScanner returns a new instance of Scanner.
+// A reader able to unread a rune, like a strings.Reader, is read directly without buffer.
 func NewScanner(r io.Reader) *Scanner {
-	return &Scanner{r: bufio.NewReader(r)}
+	s := &Scanner{}
+	s.Init(r)
+	return s
+}
+
+// Init prepares the scanner to scan the reader, reusing its internal buffer.
+// The position is reset at the beginning of the input.
+func (s *Scanner) Init(r io.Reader) {
+	switch rs, ok := r.(io.RuneScanner); {
+	case ok:
+		s.r = rs
+	case s.buf == nil:
+		s.buf = bufio.NewReader(r)
+		s.r = s.buf
+	default:
+		s.buf.Reset(r)
+		s.r = s.buf
+	}
+	s.pos, s.prev, s.back = Pos{Line: 1, Column: 1}, Pos{}, false
+}
+
+// Pos returns the position of the next rune to scan.
+func (s *Scanner) Pos() Pos {
+	return s.pos
+}
+
+// ScanPos returns the next token, its literal value and the position where it begins.
+func (s *Scanner) ScanPos() (Token, string, Pos) {
+	pos := s.pos
+	tk, literal := s.Scan()
+	return tk, literal, pos
 }
 
 // Scan returns the next token and literal value.
 func (s *Scanner) Scan() (Token, string) {
 	// Get the next rune.
 	r := s.read()
+	if r == bom && s.prev.Offset == 0 {
+		// Skips the byte order mark at the beginning of the input.
+		s.pos.Column = 1
+		r = s.read()
+	}
 	if isWhitespace(r) {
 		// Consume all contiguous whitespace.
 		s.unread()
@@ -35,6 +99,10 @@ func (s *Scanner) Scan() (Token, string) {
 		// Consume as string.
 		s.unread()
 		return s.scanQuotedString()
+	} else if r == '`' {
+		// Consume as an identifier, even a reserved word.
+		s.unread()
+		return s.scanQuotedIdentifier()
 	} else if isLetter(r) {
 		// A keyword begins by a letter.
 		// Consume as an identifier or reserved word.
@@ -44,6 +112,10 @@ func (s *Scanner) Scan() (Token, string) {
 		// Consume as a number.
 		s.unread()
 		return s.scanNumber()
+	} else if r == '@' || r == ':' {
+		// Consume as a named placeholder.
+		s.unread()
+		return s.scanNamedPlaceholder()
 	}
 
 	// Otherwise read the individual character.
@@ -86,20 +158,55 @@ func (s *Scanner) Scan() (Token, string) {
 		return INFERIOR, string(r)
 	case '\\':
 		// Deal with \G or lowercase version.
-		if r := s.read(); r == 'G' || r == 'g' {
+		// Any other character after the backslash is illegal.
+		switch r := s.read(); r {
+		case 'G', 'g':
 			return G_MODIFIER, fmt.Sprintf("\\%c", r)
+		case eof:
+		default:
+			return ILLEGAL, fmt.Sprintf("\\%c", r)
 		}
-		s.unread()
 	case ';':
 		return SEMICOLON, string(r)
+	case '?':
+		return PLACEHOLDER, string(r)
 	}
 	return ILLEGAL, string(r)
 }
 
+// identLimit returns the maximum size in bytes of the identifiers, value literals and numbers.
+func (s *Scanner) identLimit() int {
+	if s.maxIdent > 0 {
+		return s.maxIdent
+	}
+	return DefaultMaxIdentifierBytes
+}
+
+// stringLimit returns the maximum size in bytes of the strings.
+func (s *Scanner) stringLimit() int {
+	if s.maxString > 0 {
+		return s.maxString
+	}
+	return DefaultMaxStringBytes
+}
+
+// literal returns the emptied buffer of the literal of the token to scan.
+// It is reused between the tokens to only allocate their string.
+func (s *Scanner) literal() *bytes.Buffer {
+	s.tok.Reset()
+	return &s.tok
+}
+
+// full returns true if the rune can not be added to the buffer without exceeding the limit.
+func full(buf *bytes.Buffer, r rune, limit int) bool {
+	return buf.Len()+utf8.RuneLen(r) > limit
+}
+
 // scanIdentifier consumes the current rune and all contiguous literal runes.
+// Beyond the maximum size, the token TOO_LONG is returned with the first runes.
 func (s *Scanner) scanIdentifier() (Token, string) {
 	// Create a buffer and read the current character into it.
-	var buf bytes.Buffer
+	buf := s.literal()
 	buf.WriteRune(s.read())
 
 	// Read every subsequent character of this token into the buffer.
@@ -112,6 +219,10 @@ func (s *Scanner) scanIdentifier() (Token, string) {
 			s.unread()
 			break
 		} else {
+			if full(buf, r, s.identLimit()) {
+				s.unread()
+				return TOO_LONG, buf.String()
+			}
 			if r == '.' {
 				valueLiteral = true
 			}
@@ -125,115 +236,126 @@ func (s *Scanner) scanIdentifier() (Token, string) {
 	}
 
 	// If the string matches a reserved keyword then return it.
-	switch strings.ToUpper(buf.String()) {
-	case "DESCRIBE":
-		return DESCRIBE, buf.String()
-	case "SELECT":
-		return SELECT, buf.String()
-	case "CREATE":
-		return CREATE, buf.String()
-	case "REPLACE":
-		return REPLACE, buf.String()
-	case "VIEW":
-		return VIEW, buf.String()
-	case "SHOW":
-		return SHOW, buf.String()
-	case "FULL":
-		return FULL, buf.String()
-	case "TABLES":
-		return TABLES, buf.String()
-	case "DISTINCT":
-		return DISTINCT, buf.String()
-	case "AS":
-		return AS, buf.String()
-	case "FROM":
-		return FROM, buf.String()
-	case "WHERE":
-		return WHERE, buf.String()
-	case "LIKE":
-		return LIKE, buf.String()
-	case "WITH":
-		return WITH, buf.String()
-	case "AND":
-		return AND, buf.String()
-	case "OR":
-		return OR, buf.String()
-	case "IN":
-		return IN, buf.String()
-	case "NOT_IN":
-		return NOT_IN, buf.String()
-	case "STARTS_WITH":
-		return STARTS_WITH, buf.String()
-	case "STARTS_WITH_IGNORE_CASE":
-		return STARTS_WITH_IGNORE_CASE, buf.String()
-	case "CONTAINS":
-		return CONTAINS, buf.String()
-	case "CONTAINS_IGNORE_CASE":
-		return CONTAINS_IGNORE_CASE, buf.String()
-	case "DOES_NOT_CONTAIN":
-		return DOES_NOT_CONTAIN, buf.String()
-	case "DOES_NOT_CONTAIN_IGNORE_CASE":
-		return DOES_NOT_CONTAIN_IGNORE_CASE, buf.String()
-	case "DURING":
-		return DURING, buf.String()
-	case "GROUP":
-		return GROUP, buf.String()
-	case "ORDER":
-		return ORDER, buf.String()
-	case "BY":
-		return BY, buf.String()
-	case "ASC":
-		return ASC, buf.String()
-	case "DESC":
-		return DESC, buf.String()
-	case "LIMIT":
-		return LIMIT, buf.String()
+	str := buf.String()
+	if tk, ok := KeywordToken(str); ok {
+		return tk, str
 	}
-	return IDENTIFIER, buf.String()
+	return IDENTIFIER, str
+}
+
+// scanNamedPlaceholder consumes the prefix of a named placeholder, @ or :, and its name.
+// The name must begin by a letter, otherwise the prefix is illegal.
+func (s *Scanner) scanNamedPlaceholder() (Token, string) {
+	buf := s.literal()
+	buf.WriteRune(s.read())
+	r := s.read()
+	if !isLetter(r) {
+		s.unread()
+		return ILLEGAL, buf.String()
+	}
+	buf.WriteRune(r)
+	for {
+		if r := s.read(); r == eof {
+			break
+		} else if !isLiteral(r) {
+			s.unread()
+			break
+		} else if full(buf, r, s.identLimit()) {
+			s.unread()
+			return TOO_LONG, buf.String()
+		} else {
+			buf.WriteRune(r)
+		}
+	}
+	return NAMED_PLACEHOLDER, buf.String()
 }
 
 // scanNumber consumes all digit or dot runes.
+// A malformed number, like 1.2.3, is returned as illegal with its literal.
 func (s *Scanner) scanNumber() (tk Token, str string) {
 	// Create a buffer and read the current character into it.
-	var buf bytes.Buffer
+	buf := s.literal()
 	for {
 		if r := s.read(); r == eof {
 			break
 		} else if !isDigit(r) && r != '.' {
 			s.unread()
 			break
+		} else if full(buf, r, s.identLimit()) {
+			s.unread()
+			return TOO_LONG, buf.String()
 		} else {
 			buf.WriteRune(r)
 		}
 	}
-	// Check if it is a valid number.
+	// Check if it is a valid number: digits, optionally followed by a dot and digits.
 	str = buf.String()
-	if _, err := strconv.Atoi(str); err == nil {
-		tk = DIGIT
-	} else if _, err := strconv.ParseFloat(str, 64); err == nil {
-		tk = DECIMAL
+	switch i := strings.IndexByte(str, '.'); {
+	case i < 0:
+		return DIGIT, str
+	case i < len(str)-1 && strings.IndexByte(str[i+1:], '.') < 0:
+		return DECIMAL, str
+	}
+	return ILLEGAL, str
+}
+
+// scanQuotedIdentifier consumes the current backtick and all runes after it
+// until the next backtick which is not doubled.
+// The name is returned without the backticks and with the doubled ones unescaped.
+func (s *Scanner) scanQuotedIdentifier() (Token, string) {
+	s.read()
+	buf := s.literal()
+	for {
+		r := s.read()
+		if r == eof {
+			return UNTERMINATED_STRING, "`" + buf.String()
+		} else if full(buf, r, s.identLimit()) {
+			return TOO_LONG, "`" + buf.String()
+		} else if r != '`' {
+			buf.WriteRune(r)
+		} else if r = s.read(); r == '`' {
+			buf.WriteRune(r)
+		} else {
+			s.unread()
+			break
+		}
+	}
+	if buf.Len() == 0 {
+		return ILLEGAL, "``"
 	}
-	return
+	return IDENTIFIER, buf.String()
 }
 
 // scanQuotedString consumes the current rune and all runes after it
 // until the next unprotected quote character.
+// The value is returned without its quotes and with the protected quotes and backslashes unescaped.
+// Without closing quote, the token UNTERMINATED_STRING is returned with the opening quote.
 func (s *Scanner) scanQuotedString() (Token, string) {
 	// Create a buffer and add the single or double quote into it.
 	quote := s.read()
 	if quote != '\'' && quote != '"' {
 		return ILLEGAL, string(quote)
 	}
-	var buf bytes.Buffer
+	buf := s.literal()
 	for {
 		r := s.read()
 		if r == eof {
-			return ILLEGAL, buf.String()
+			return UNTERMINATED_STRING, string(quote) + buf.String()
+		} else if full(buf, r, s.stringLimit()) {
+			return TOO_LONG, string(quote) + buf.String()
 		} else if r == '\\' {
-			buf.WriteRune(r)
-			// Only the character immediately after the escape can itself be a backslash or quote.
-			// Thus, we only need to protect the first character after the backslash.
-			buf.WriteRune(s.read())
+			// Only a quote or a backslash can be protected by a backslash.
+			// Any other escape sequence is kept as is.
+			switch n := s.read(); n {
+			case '\'', '"', '\\':
+				buf.WriteRune(n)
+			case eof:
+				buf.WriteRune(r)
+				return UNTERMINATED_STRING, string(quote) + buf.String()
+			default:
+				buf.WriteRune(r)
+				buf.WriteRune(n)
+			}
 		} else if r != quote {
 			buf.WriteRune(r)
 		} else {
@@ -244,34 +366,48 @@ func (s *Scanner) scanQuotedString() (Token, string) {
 }
 
 // scanWhitespace consumes the current rune and all contiguous whitespace.
+// Only the first runes, until the maximum size of an identifier, are kept in the literal.
 func (s *Scanner) scanWhitespace() (Token, string) {
-	var buf bytes.Buffer
+	buf := s.literal()
 	for {
 		if r := s.read(); r == eof {
 			break
 		} else if !isWhitespace(r) {
 			s.unread()
 			break
-		} else {
+		} else if !full(buf, r, s.identLimit()) {
 			buf.WriteRune(r)
 		}
 	}
 	return WHITE_SPACE, buf.String()
 }
 
-// read reads the next rune from the bufferred reader.
+// read reads the next rune from the reader.
 // Returns the rune(0) if an error occurs (or io.EOF is returned).
+// The position is moved forward by the rune, a new line begins after '\n'.
 func (s *Scanner) read() rune {
-	ch, _, err := s.r.ReadRune()
+	ch, size, err := s.r.ReadRune()
 	if err != nil {
+		s.back = false
 		return eof
 	}
+	s.prev, s.back = s.pos, true
+	s.pos.Offset += size
+	if ch == '\n' {
+		s.pos.Line++
+		s.pos.Column = 1
+	} else {
+		s.pos.Column++
+	}
 	return ch
 }
 
 // unread places the previously read rune back on the reader.
+// The position is moved back before it, even on a previous line.
 func (s *Scanner) unread() {
-	_ = s.r.UnreadRune()
+	if s.r.UnreadRune() == nil && s.back {
+		s.pos, s.back = s.prev, false
+	}
 }
 
 // isDate return true if the string is a date as expected by Adwords.
@@ -282,17 +418,51 @@ func isDate(s string) bool {
 	return false
 }
 
-// isDateRange return true if the string is a date range literal.
+// isISODate returns true if the string has the shape of an ISO date: YYYY-MM-DD.
+// It does not check that the date exists.
+func isISODate(s string) bool {
+	if len(s) != 10 {
+		return false
+	}
+	for i, r := range s {
+		if i == 4 || i == 7 {
+			if r != '-' {
+				return false
+			}
+		} else if !isDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDateRange return true if the string is a date range literal, whatever its case.
 func isDateRangeLiteral(s string) bool {
-	switch s {
-	case "TODAY", "YESTERDAY",
-		"THIS_WEEK_SUN_TODAY", "THIS_WEEK_MON_TODAY",
-		"LAST_WEEK", "LAST_7_DAYS", "LAST_14_DAYS",
-		"LAST_30_DAYS", "LAST_BUSINESS_WEEK",
-		"LAST_WEEK_SUN_SAT", "THIS_MONTH":
-		return true
+	dateRanges.RLock()
+	defer dateRanges.RUnlock()
+	return dateRanges.m[strings.ToUpper(s)]
+}
+
+// isNamedPlaceholder returns true if the string is a named placeholder: @name or :name.
+func isNamedPlaceholder(s string) bool {
+	return len(s) > 1 && (s[0] == '@' || s[0] == ':')
+}
+
+// boolLiterals maps the boolean literals, in their canonical form, to their value.
+var boolLiterals = map[string]bool{"TRUE": true, "FALSE": false}
+
+// isBoolLiteral returns true if the string is a boolean literal, whatever its case.
+func isBoolLiteral(s string) bool {
+	_, ok := boolLiterals[strings.ToUpper(s)]
+	return ok
+}
+
+// normalizeValue returns the boolean literals in upper case, the other values unchanged.
+func normalizeValue(s string) string {
+	if isBoolLiteral(s) {
+		return strings.ToUpper(s)
 	}
-	return false
+	return s
 }
 
 // isDigit returns true if the rune is a digit.
@@ -302,34 +472,34 @@ func isDigit(r rune) bool {
 
 // isFunction returns true if it is an aggregate function.
 func isFunction(s string) bool {
-	switch strings.ToUpper(s) {
-	case "AVG", "COUNT", "MAX", "MIN", "SUM":
-		return true
+	s = strings.ToUpper(s)
+	for _, f := range functionNames {
+		if f == s {
+			return true
+		}
 	}
 	return false
 }
 
-// isLetter returns true if the rune is a letter.
+// isLetter returns true if the rune is a letter, whatever its alphabet.
 func isLetter(r rune) bool {
-	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+	return unicode.IsLetter(r)
 }
 
-// isLiteral returns true if the rune is a literal [a-zA-Z0-9_]
+// isLiteral returns true if the rune is a literal: a letter, a digit or _
 func isLiteral(r rune) bool {
-	return r == '_' || isDigit(r) || isLetter(r)
+	return r == '_' || unicode.IsDigit(r) || isLetter(r)
 }
 
 // isOperator returns true if the token is an operator
 func isOperator(tk Token) bool {
-	switch tk {
-	case EQUAL, DIFFERENT, SUPERIOR, SUPERIOR_OR_EQUAL,
-		INFERIOR, INFERIOR_OR_EQUAL, IN, NOT_IN,
-		STARTS_WITH, STARTS_WITH_IGNORE_CASE,
-		CONTAINS, CONTAINS_IGNORE_CASE,
-		DOES_NOT_CONTAIN, DOES_NOT_CONTAIN_IGNORE_CASE:
-		return true
-	}
-	return false
+	_, ok := operators[tk]
+	return ok
+}
+
+// isListOperator returns true if the operator expects a list of values.
+func isListOperator(tk Token) bool {
+	return tk == IN || tk == NOT_IN
 }
 
 // isQuote returns if the rune is a single quote or double quote.
@@ -342,7 +512,8 @@ func isValueLiteral(r rune) bool {
 	return r == '.' || isLiteral(r)
 }
 
-// isWhitespace returns true if the rune is a space, tab, or newline.
+// isWhitespace returns true if the rune is a space, tab, newline, carriage return
+// or any other Unicode white space.
 func isWhitespace(r rune) bool {
-	return r == ' ' || r == '\t' || r == '\n'
+	return unicode.IsSpace(r)
 }
@@ -20,9 +20,13 @@ func TestScanner_Scan(t *testing.T) {
 		{s: `8`, t: awql.DIGIT, l: `8`},
 		{s: `1.0`, t: awql.DECIMAL, l: `1.0`},
 		{s: `2.0b`, t: awql.DECIMAL, l: `2.0`},
+		{s: `1.2.3`, t: awql.ILLEGAL, l: `1.2.3`},
+		{s: `20161224.`, t: awql.ILLEGAL, l: `20161224.`},
+		{s: `123abc`, t: awql.DIGIT, l: `123`},
 		{s: `\G`, t: awql.G_MODIFIER, l: `\G`},
 		{s: `\g`, t: awql.G_MODIFIER, l: `\g`},
-		{s: `\p`, t: awql.ILLEGAL, l: `\`},
+		{s: `\p`, t: awql.ILLEGAL, l: `\p`},
+		{s: `\`, t: awql.ILLEGAL, l: `\`},
 
 		// Misc characters
 		{s: `*`, t: awql.ASTERISK, l: `*`},
@@ -38,10 +42,20 @@ func TestScanner_Scan(t *testing.T) {
 		{s: `   a`, t: awql.WHITE_SPACE, l: `   `},
 		{s: "\t", t: awql.WHITE_SPACE, l: "\t"},
 		{s: "\n", t: awql.WHITE_SPACE, l: "\n"},
+		{s: "\r\n", t: awql.WHITE_SPACE, l: "\r\n"},
+		{s: "\u00a0\v\f", t: awql.WHITE_SPACE, l: "\u00a0\v\f"},
+		{s: "\ufeffSELECT", t: awql.SELECT, l: "SELECT"},
+		{s: "\ufeff ", t: awql.WHITE_SPACE, l: " "},
+		{s: " \ufeff", t: awql.WHITE_SPACE, l: " "},
 		{s: `'string'`, t: awql.STRING, l: `string`},
 		{s: `"string"`, t: awql.STRING, l: `string`},
-		{s: `"stri`, t: awql.ILLEGAL, l: `stri`},
-		{s: `"my \"tiny\" string"`, t: awql.STRING, l: `my \"tiny\" string`},
+		{s: `"stri`, t: awql.UNTERMINATED_STRING, l: `"stri`},
+		{s: `"my \"tiny\" string"`, t: awql.STRING, l: `my "tiny" string`},
+		{s: `'it\'s'`, t: awql.STRING, l: `it's`},
+		{s: `'C:\\rv\\'`, t: awql.STRING, l: `C:\rv\`},
+		{s: `'\d+'`, t: awql.STRING, l: `\d+`},
+		{s: `'oops\`, t: awql.UNTERMINATED_STRING, l: `'oops\`},
+		{s: `'oops\'`, t: awql.UNTERMINATED_STRING, l: `'oops'`},
 		{s: `a.b`, t: awql.VALUE_LITERAL, l: `a.b`},
 
 		// Operator
@@ -65,6 +79,15 @@ func TestScanner_Scan(t *testing.T) {
 		{s: `Criteria`, t: awql.IDENTIFIER, l: `Criteria`},
 		{s: `CRITERIA_PERFORMANCE_REPORT`, t: awql.IDENTIFIER, l: `CRITERIA_PERFORMANCE_REPORT`},
 		{s: `Z6P0_C3P0_-`, t: awql.IDENTIFIER, l: `Z6P0_C3P0_`},
+		{s: `coût`, t: awql.IDENTIFIER, l: `coût`},
+		{s: `Élément_2 `, t: awql.IDENTIFIER, l: `Élément_2`},
+		{s: `報告書`, t: awql.IDENTIFIER, l: `報告書`},
+		{s: `sélect`, t: awql.IDENTIFIER, l: `sélect`},
+		{s: "`ORDER`", t: awql.IDENTIFIER, l: `ORDER`},
+		{s: "`my cost` ", t: awql.IDENTIFIER, l: `my cost`},
+		{s: "`a``b`", t: awql.IDENTIFIER, l: "a`b"},
+		{s: "`oops", t: awql.UNTERMINATED_STRING, l: "`oops"},
+		{s: "``", t: awql.ILLEGAL, l: "``"},
 
 		// Keywords
 		{s: `DESCRIBE`, t: awql.DESCRIBE, l: `DESCRIBE`},
@@ -73,6 +96,7 @@ func TestScanner_Scan(t *testing.T) {
 		{s: `replace`, t: awql.REPLACE, l: `replace`},
 		{s: `VIEW`, t: awql.VIEW, l: `VIEW`},
 		{s: `SHOW`, t: awql.SHOW, l: `SHOW`},
+		{s: `use`, t: awql.USE, l: `use`},
 		{s: `FULL`, t: awql.FULL, l: `FULL`},
 		{s: `TABLES`, t: awql.TABLES, l: `TABLES`},
 		{s: `DISTINCT`, t: awql.DISTINCT, l: `DISTINCT`},
@@ -90,6 +114,28 @@ func TestScanner_Scan(t *testing.T) {
 		{s: `ASC`, t: awql.ASC, l: `ASC`},
 		{s: `DESC`, t: awql.DESC, l: `DESC`},
 		{s: `LIMIT`, t: awql.LIMIT, l: `LIMIT`},
+		{s: `except`, t: awql.EXCEPT, l: `except`},
+		{s: `?`, t: awql.PLACEHOLDER, l: `?`},
+		{s: `@account`, t: awql.NAMED_PLACEHOLDER, l: `@account`},
+		{s: `:start_date,`, t: awql.NAMED_PLACEHOLDER, l: `:start_date`},
+		{s: `@1`, t: awql.ILLEGAL, l: `@`},
+
+		// Tokens exceeding their maximum size.
+		{s: strings.Repeat("a", awql.DefaultMaxIdentifierBytes+1), t: awql.TOO_LONG, l: strings.Repeat("a", awql.DefaultMaxIdentifierBytes)},
+		{s: strings.Repeat("1", awql.DefaultMaxIdentifierBytes+1), t: awql.TOO_LONG, l: strings.Repeat("1", awql.DefaultMaxIdentifierBytes)},
+		{s: "'" + strings.Repeat("é", awql.DefaultMaxStringBytes), t: awql.TOO_LONG, l: "'" + strings.Repeat("é", awql.DefaultMaxStringBytes/2)},
+		{s: strings.Repeat(" ", awql.DefaultMaxIdentifierBytes+1) + "a", t: awql.WHITE_SPACE, l: strings.Repeat(" ", awql.DefaultMaxIdentifierBytes)},
+	}
+
+	// Every token must be emitted by the scanner, except the lists built by the parser.
+	covered := map[awql.Token]bool{awql.STRING_LIST: true, awql.VALUE_LITERAL_LIST: true}
+	for _, tt := range tests {
+		covered[tt.t] = true
+	}
+	for tk := awql.ILLEGAL; !strings.HasPrefix(tk.String(), "Token("); tk++ {
+		if !covered[tk] {
+			t.Errorf("Expected a test scanning the token %v", tk)
+		}
 	}
 
 	for i, tt := range tests {
@@ -102,3 +148,102 @@ func TestScanner_Scan(t *testing.T) {
 		}
 	}
 }
+
+// Ensure the scanner splits the vertical display modifier from the next statement.
+func TestScanner_ScanGModifier(t *testing.T) {
+	var tests = []struct {
+		s  string
+		tk []awql.Token
+	}{
+		{s: `LIMIT 5\GDESC`, tk: []awql.Token{awql.LIMIT, awql.WHITE_SPACE, awql.DIGIT, awql.G_MODIFIER, awql.DESC}},
+		{s: `REPORT\gSHOW`, tk: []awql.Token{awql.IDENTIFIER, awql.G_MODIFIER, awql.SHOW}},
+		{s: `REPORT\\G`, tk: []awql.Token{awql.IDENTIFIER, awql.ILLEGAL, awql.IDENTIFIER}},
+		{s: `123abc`, tk: []awql.Token{awql.DIGIT, awql.IDENTIFIER}},
+	}
+	for i, tt := range tests {
+		s := awql.NewScanner(strings.NewReader(tt.s))
+		for j, exp := range tt.tk {
+			if tk, l := s.Scan(); tk != exp {
+				t.Errorf("%d. %q token #%d mismatch: exp=%v got=%v <%q>", i, tt.s, j, exp, tk, l)
+			}
+		}
+		if tk, _ := s.Scan(); tk != awql.EOF {
+			t.Errorf("%d. %q expected the end, got=%v", i, tt.s, tk)
+		}
+	}
+}
+
+// Ensure the scanner tracks the position of each token.
+func TestScanner_ScanPos(t *testing.T) {
+	q := "SELECT Cost\nFROM  REPORT\n\tWHERE Name = 'é'\n;"
+	var tests = []struct {
+		tk  awql.Token
+		pos awql.Pos
+	}{
+		{tk: awql.SELECT, pos: awql.Pos{Line: 1, Column: 1, Offset: 0}},
+		{tk: awql.WHITE_SPACE, pos: awql.Pos{Line: 1, Column: 7, Offset: 6}},
+		{tk: awql.IDENTIFIER, pos: awql.Pos{Line: 1, Column: 8, Offset: 7}},
+		{tk: awql.WHITE_SPACE, pos: awql.Pos{Line: 1, Column: 12, Offset: 11}},
+		{tk: awql.FROM, pos: awql.Pos{Line: 2, Column: 1, Offset: 12}},
+		{tk: awql.WHITE_SPACE, pos: awql.Pos{Line: 2, Column: 5, Offset: 16}},
+		{tk: awql.IDENTIFIER, pos: awql.Pos{Line: 2, Column: 7, Offset: 18}},
+		{tk: awql.WHITE_SPACE, pos: awql.Pos{Line: 2, Column: 13, Offset: 24}},
+		{tk: awql.WHERE, pos: awql.Pos{Line: 3, Column: 2, Offset: 26}},
+		{tk: awql.WHITE_SPACE, pos: awql.Pos{Line: 3, Column: 7, Offset: 31}},
+		{tk: awql.IDENTIFIER, pos: awql.Pos{Line: 3, Column: 8, Offset: 32}},
+		{tk: awql.WHITE_SPACE, pos: awql.Pos{Line: 3, Column: 12, Offset: 36}},
+		{tk: awql.EQUAL, pos: awql.Pos{Line: 3, Column: 13, Offset: 37}},
+		{tk: awql.WHITE_SPACE, pos: awql.Pos{Line: 3, Column: 14, Offset: 38}},
+		{tk: awql.STRING, pos: awql.Pos{Line: 3, Column: 15, Offset: 39}},
+		// The accented letter uses two bytes.
+		{tk: awql.WHITE_SPACE, pos: awql.Pos{Line: 3, Column: 18, Offset: 43}},
+		{tk: awql.SEMICOLON, pos: awql.Pos{Line: 4, Column: 1, Offset: 44}},
+		{tk: awql.EOF, pos: awql.Pos{Line: 4, Column: 2, Offset: 45}},
+	}
+	s := awql.NewScanner(strings.NewReader(q))
+	for i, tt := range tests {
+		if tk, l, pos := s.ScanPos(); tk != tt.tk || pos != tt.pos {
+			t.Errorf("%d. mismatch: exp=%v at %#v got=%v <%q> at %#v", i, tt.tk, tt.pos, tk, l, pos)
+		}
+	}
+	if pos := s.Pos(); pos.String() != "4:2" {
+		t.Errorf("Expected the end at 4:2, got=%v", pos)
+	}
+}
+
+// Ensure the scanner can be reused without keeping the state of the previous input.
+func TestScanner_Init(t *testing.T) {
+	s := awql.NewScanner(strings.NewReader("SELECT\nCost"))
+	s.Scan()
+	s.Scan()
+	s.Init(strings.NewReader("DESC"))
+	if pos := s.Pos(); pos != (awql.Pos{Line: 1, Column: 1}) {
+		t.Errorf("Expected the beginning of the input, got=%#v", pos)
+	}
+	if tk, l := s.Scan(); tk != awql.DESC || l != "DESC" {
+		t.Errorf("Expected DESC, got=%v <%q>", tk, l)
+	}
+	if tk, _, pos := s.ScanPos(); tk != awql.EOF || pos.String() != "1:5" {
+		t.Errorf("Expected the end at 1:5, got=%v at %v", tk, pos)
+	}
+}
+
+func BenchmarkScanner_Scan(b *testing.B) {
+	// Builds a query of 10k tokens, mainly keywords and identifiers.
+	words := []string{"SELECT", "CampaignName", "FROM", "where", "Cost", "ORDER", "By", "AdGroupId", "desc", "LIMIT"}
+	var list []string
+	for i := 0; i < 5000; i++ {
+		list = append(list, words[i%len(words)])
+	}
+	q := strings.Join(list, " ")
+	s := awql.NewScanner(nil)
+	r := strings.NewReader(q)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Reset(q)
+		s.Init(r)
+		for tk, _ := s.Scan(); tk != awql.EOF; tk, _ = s.Scan() {
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package awqlparse
+
+// Schema is the interface that must be implemented to describe the reports and their columns.
+type Schema interface {
+	HasTable(table string) bool
+	HasColumn(table, column string) bool
+	Tables() []string
+}
+
+// Validate checks the tables and columns used by the statement against the schema.
+// It returns an error for each unknown table, each unknown column of the select statements
+// and for the column of a SHOW...WITH statement if no table has it.
+// The wildcard * is accepted and the positions are resolved to the field they point at.
+func Validate(stmt Stmt, s Schema) []error {
+	var errs []error
+	Walk(stmt, func(node interface{}) bool {
+		switch n := node.(type) {
+		case CreateViewStmt:
+			// The view is a new table, only its source query must be checked.
+			if v := n.SourceQuery(); !isNilSelect(v) {
+				errs = append(errs, Validate(v, s)...)
+			}
+			return false
+		case ShowStmt:
+			if name, ok := n.WithFieldName(); ok && !hasColumn(s, name) {
+				errs = append(errs, NewXParserError(ErrMsgUnknownColumn, name))
+			}
+		case DataStmt:
+			table := n.SourceName()
+			if !s.HasTable(table) {
+				errs = append(errs, NewXParserError(ErrMsgUnknownTable, table))
+				return false
+			}
+			for _, c := range ReferencedColumns(n) {
+				if c.ColumnName != "*" && !s.HasColumn(table, c.ColumnName) {
+					errs = append(errs, NewXParserError(ErrMsgUnknownColumn, c.ColumnName))
+				}
+			}
+			return false
+		}
+		return true
+	})
+	return errs
+}
+
+// hasColumn returns true if one of the tables of the schema has this column.
+func hasColumn(s Schema, column string) bool {
+	for _, t := range s.Tables() {
+		if s.HasColumn(t, column) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package awqlparse_test
+
+import (
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+// schema maps each table to its columns.
+type schema map[string][]string
+
+func (s schema) HasTable(table string) bool {
+	_, ok := s[table]
+	return ok
+}
+
+func (s schema) HasColumn(table, column string) bool {
+	for _, c := range s[table] {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+func (s schema) Tables() []string {
+	var tables []string
+	for t := range s {
+		tables = append(tables, t)
+	}
+	return tables
+}
+
+func TestValidate(t *testing.T) {
+	s := schema{
+		"CAMPAIGN_PERFORMANCE_REPORT": {"CampaignId", "CampaignName", "Clicks"},
+		"ADGROUP_PERFORMANCE_REPORT":  {"AdGroupId", "Clicks"},
+	}
+	var tests = []struct {
+		q    string
+		errs []string
+	}{
+		{q: `SELECT CampaignId, SUM(Clicks) AS c FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName = "rv" GROUP BY 1 ORDER BY c`},
+		{q: `SELECT COUNT(*), MAX(1) FROM ADGROUP_PERFORMANCE_REPORT`},
+		{
+			q:    `SELECT CampaignId, Cost FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Impressions > 0 ORDER BY 2`,
+			errs: []string{"Cost", "Impressions"},
+		},
+		{q: `SELECT CampaignId FROM KEYWORDS_PERFORMANCE_REPORT`, errs: []string{"KEYWORDS_PERFORMANCE_REPORT"}},
+		{q: `SELECT * EXCEPT (Cost) FROM CAMPAIGN_PERFORMANCE_REPORT`, errs: []string{"Cost"}},
+		{q: `CREATE VIEW rv (id) AS SELECT AdGroupId FROM ADGROUP_PERFORMANCE_REPORT`},
+		{q: `CREATE VIEW rv AS SELECT CampaignId FROM ADGROUP_PERFORMANCE_REPORT`, errs: []string{"CampaignId"}},
+		{q: `DESC CAMPAIGN_PERFORMANCE_REPORT AdGroupId`, errs: []string{"AdGroupId"}},
+		{q: `SHOW TABLES WITH AdGroupId`},
+		{q: `SHOW TABLES WITH Cost`, errs: []string{"Cost"}},
+		{q: `USE 123`},
+	}
+
+	for i, tt := range tests {
+		stmts, err := awql.NewParser(strings.NewReader(tt.q)).Parse()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		}
+		errs := awql.Validate(stmts[0], s)
+		if len(errs) != len(tt.errs) {
+			t.Errorf("%d. Expected %d errors with %q, received %v", i, len(tt.errs), tt.q, errs)
+			continue
+		}
+		for y, err := range errs {
+			if !strings.Contains(err.Error(), tt.errs[y]) {
+				t.Errorf("%d. Expected %q in the error with %q, received %v", i, tt.errs[y], tt.q, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package awqlparse
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default column of the dates used by the during clause, as the segment of the reports.
+const defaultDateColumn = "Date"
+
+// SQLOptions represents the settings of the conversion into SQL.
+type SQLOptions struct {
+	// DateColumn is the column of the dates compared with the during clause.
+	// By default, the Date column is used.
+	DateColumn string
+	// Now is the reference time used to resolve the date range literals, as YESTERDAY.
+	// By default, the current time is used.
+	Now time.Time
+}
+
+// dateColumn returns the column of the dates to use.
+func (o SQLOptions) dateColumn() string {
+	if o.DateColumn == "" {
+		return defaultDateColumn
+	}
+	return o.DateColumn
+}
+
+// now returns the reference time to use.
+func (o SQLOptions) now() time.Time {
+	if o.Now.IsZero() {
+		return time.Now()
+	}
+	return o.Now
+}
+
+// ToSQL converts the select statement into a parameterized standard SQL query on the given table,
+// with the default options: the during clause is compared with the Date column and its date range
+// literal is resolved relatively to the current time. See ToSQLWith for the details.
+func ToSQL(stmt SelectStmt, table string) (string, []interface{}, error) {
+	return ToSQLWith(stmt, table, SQLOptions{})
+}
+
+// ToSQLWith converts the select statement into a parameterized standard SQL query on the given table.
+// Each value of the conditions is replaced by a question mark and returned as argument.
+// The during clause is resolved with DuringRange and becomes a BETWEEN condition on the date column,
+// with dates formatted as YYYY-MM-DD, or a lower than or equal condition with ALL_TIME.
+// The aggregate functions, the distinct fields and the aliases are kept.
+// The GROUP BY and ORDER BY clauses use the column positions, or its alias if it has one.
+// The WITH ROLLUP modifier becomes a ROLLUP grouping.
+func ToSQLWith(stmt SelectStmt, table string, opts SQLOptions) (string, []interface{}, error) {
+	var (
+		buf  bytes.Buffer
+		args []interface{}
+	)
+	buf.WriteString("SELECT ")
+	if stmt.Distinct() {
+		buf.WriteString("DISTINCT ")
+	}
+	for i, c := range stmt.Columns() {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		f := quoteIdentifier(c.Name())
+		method, distinct, ok := c.Aggregate()
+		if distinct {
+			f = "DISTINCT " + f
+		}
+		if ok {
+			f = strings.ToUpper(method) + "(" + f + ")"
+		}
+		buf.WriteString(f)
+		if alias, ok := c.Alias(); ok {
+			buf.WriteString(" AS ")
+			buf.WriteString(quoteIdentifier(alias))
+		}
+	}
+	buf.WriteString(" FROM ")
+	buf.WriteString(quoteIdentifier(table))
+
+	// Adds the conditions, with the date range as last one.
+	var conds []string
+	for _, c := range stmt.ConditionList() {
+		q, a, ok := sqlCondition(c)
+		if !ok {
+			return "", nil, NewXParserError(ErrMsgUnmappable, c.Name()+" "+c.Operator())
+		}
+		conds = append(conds, q)
+		args = append(args, a...)
+	}
+	if d := stmt.DuringList(); len(d) > 0 {
+		start, end, err := stmt.DuringRange(opts.now())
+		if err != nil {
+			return "", nil, NewXParserError(ErrMsgUnmappable, "DURING "+strings.Join(d, ","))
+		}
+		const layout = "2006-01-02"
+		if start.IsZero() {
+			// All the time, until today.
+			conds = append(conds, quoteIdentifier(opts.dateColumn())+" <= ?")
+			args = append(args, end.Format(layout))
+		} else {
+			conds = append(conds, quoteIdentifier(opts.dateColumn())+" BETWEEN ? AND ?")
+			args = append(args, start.Format(layout), end.Format(layout))
+		}
+	}
+	if len(conds) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(conds, " AND "))
+	}
+
+	for i, g := range stmt.GroupList() {
+		if i > 0 {
+			buf.WriteString(", ")
+		} else {
+			buf.WriteString(" GROUP BY ")
+			if stmt.WithRollup() {
+				buf.WriteString("ROLLUP(")
+			}
+		}
+		buf.WriteString(sqlPosition(stmt, g))
+	}
+	if stmt.WithRollup() && len(stmt.GroupList()) > 0 {
+		buf.WriteString(")")
+	}
+	for i, o := range stmt.OrderList() {
+		if i > 0 {
+			buf.WriteString(", ")
+		} else {
+			buf.WriteString(" ORDER BY ")
+		}
+		buf.WriteString(sqlPosition(stmt, o))
+		if o.SortDescending() {
+			buf.WriteString(" DESC")
+		}
+	}
+	if rc, ok := stmt.PageSize(); ok {
+		buf.WriteString(" LIMIT ")
+		buf.WriteString(strconv.Itoa(rc))
+		if si := stmt.StartIndex(); si > 0 {
+			buf.WriteString(" OFFSET ")
+			buf.WriteString(strconv.Itoa(si))
+		}
+	}
+
+	return buf.String(), args, nil
+}
+
+// sqlPosition returns the alias of the field at this position if it has one, its position otherwise.
+func sqlPosition(stmt SelectStmt, f FieldPosition) string {
+	if pos := f.Position(); pos > 0 && pos <= len(stmt.Columns()) {
+		if alias, ok := stmt.Columns()[pos-1].Alias(); ok {
+			return quoteIdentifier(alias)
+		}
+	}
+	return strconv.Itoa(f.Position())
+}
+
+// sqlCondition returns the condition with placeholders and its arguments.
+// The third parameter is false if the operator has no equivalent.
+// The text operators use LIKE, the ones ignoring the case compare the lower case values.
+func sqlCondition(c Condition) (string, []interface{}, bool) {
+	name := quoteIdentifier(c.Name())
+	val, lit := c.Value()
+	args := make([]interface{}, len(val))
+	for i, v := range val {
+		args[i] = sqlValue(v, lit)
+	}
+	like := func(not bool, lower bool, prefix, suffix string) (string, []interface{}, bool) {
+		v := likeEscapeSQL(val[0])
+		q := name
+		if lower {
+			q = "LOWER(" + q + ")"
+			v = strings.ToLower(v)
+		}
+		if not {
+			q += " NOT"
+		}
+		return q + ` LIKE ? ESCAPE '\'`, []interface{}{prefix + v + suffix}, true
+	}
+
+	switch op := strings.ToUpper(c.Operator()); op {
+	case "=", "!=", ">", ">=", "<", "<=":
+		return name + " " + op + " ?", args[:1], true
+	case "IN", "NOT_IN":
+		if op == "NOT_IN" {
+			name += " NOT"
+		}
+		return name + " IN (?" + strings.Repeat(", ?", len(args)-1) + ")", args, true
+	case "STARTS_WITH":
+		return like(false, false, "", "%")
+	case "STARTS_WITH_IGNORE_CASE":
+		return like(false, true, "", "%")
+	case "CONTAINS":
+		return like(false, false, "%", "%")
+	case "CONTAINS_IGNORE_CASE":
+		return like(false, true, "%", "%")
+	case "DOES_NOT_CONTAIN":
+		return like(true, false, "%", "%")
+	case "DOES_NOT_CONTAIN_IGNORE_CASE":
+		return like(true, true, "%", "%")
+	}
+	return "", nil, false
+}
+
+// sqlValue returns the literal value as number if it is one, as string otherwise.
+func sqlValue(v string, literal bool) interface{} {
+	if literal {
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return i
+		}
+		// Only decimals are converted as float, to keep large integers exact.
+		if strings.Contains(v, ".") {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f
+			}
+		}
+	}
+	return v
+}
+
+// likeEscapeSQL protects the wildcards of the LIKE operator by a backslash.
+func likeEscapeSQL(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return r.Replace(s)
+}
+
+// quoteIdentifier returns the name between double quotes, as expected by the SQL standard.
+func quoteIdentifier(s string) string {
+	return `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+}
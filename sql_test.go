@@ -0,0 +1,91 @@
+package awqlparse_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestToSQL(t *testing.T) {
+	var tests = []struct {
+		fq, tq string
+		args   []interface{}
+		err    bool
+	}{
+		{
+			fq: `SELECT CampaignId, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			tq: `SELECT "CampaignId", "Clicks" FROM "report"`,
+		},
+		{
+			fq:   `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignStatus IN ["ENABLED","PAUSED"] AND Clicks > 10`,
+			tq:   `SELECT "CampaignId" FROM "report" WHERE "CampaignStatus" IN (?, ?) AND "Clicks" > ?`,
+			args: []interface{}{"ENABLED", "PAUSED", int64(10)},
+		},
+		{
+			fq:   `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName NOT_IN [rv] AND Cost >= 1.5`,
+			tq:   `SELECT "CampaignId" FROM "report" WHERE "CampaignName" NOT IN (?) AND "Cost" >= ?`,
+			args: []interface{}{"rv", 1.5},
+		},
+		{
+			fq:   `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT WHERE CampaignName STARTS_WITH "50%" AND CampaignName DOES_NOT_CONTAIN_IGNORE_CASE "RV"`,
+			tq:   `SELECT "CampaignId" FROM "report" WHERE "CampaignName" LIKE ? ESCAPE '\' AND LOWER("CampaignName") NOT LIKE ? ESCAPE '\'`,
+			args: []interface{}{`50\%%`, "%rv%"},
+		},
+		{
+			fq:   `SELECT CampaignId, SUM(Clicks) AS c, COUNT(DISTINCT AdGroupId) FROM CAMPAIGN_PERFORMANCE_REPORT DURING 20161224,20161231 GROUP BY 1 ORDER BY 2 DESC, 1 LIMIT 5, 10`,
+			tq:   `SELECT "CampaignId", SUM("Clicks") AS "c", COUNT(DISTINCT "AdGroupId") FROM "report" WHERE "Day" BETWEEN ? AND ? GROUP BY 1 ORDER BY "c" DESC, 1 LIMIT 10 OFFSET 5`,
+			args: []interface{}{"2016-12-24", "2016-12-31"},
+		},
+		{
+			fq:   `SELECT CampaignId, AdGroupId, SUM(Clicks) FROM ADGROUP_PERFORMANCE_REPORT DURING 20161224,20161231 GROUP BY 1, 2 WITH ROLLUP`,
+			tq:   `SELECT "CampaignId", "AdGroupId", SUM("Clicks") FROM "report" WHERE "Day" BETWEEN ? AND ? GROUP BY ROLLUP(1, 2)`,
+			args: []interface{}{"2016-12-24", "2016-12-31"},
+		},
+		{
+			fq:   `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING YESTERDAY`,
+			tq:   `SELECT "CampaignId" FROM "report" WHERE "Day" BETWEEN ? AND ?`,
+			args: []interface{}{"2016-12-23", "2016-12-23"},
+		},
+		{
+			fq:   `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING LAST_7_DAYS`,
+			tq:   `SELECT "CampaignId" FROM "report" WHERE "Day" BETWEEN ? AND ?`,
+			args: []interface{}{"2016-12-17", "2016-12-23"},
+		},
+		{
+			fq:   `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING ALL_TIME`,
+			tq:   `SELECT "CampaignId" FROM "report" WHERE "Day" <= ?`,
+			args: []interface{}{"2016-12-24"},
+		},
+		{
+			fq:  `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT DURING @start, @end`,
+			err: true,
+		},
+	}
+	opts := awql.SQLOptions{DateColumn: "Day", Now: time.Date(2016, 12, 24, 15, 0, 0, 0, time.UTC)}
+
+	for i, tt := range tests {
+		stmts, err := awql.NewParser(strings.NewReader(tt.fq)).Parse()
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.fq, err)
+		}
+		q, args, err := awql.ToSQLWith(stmts[0].(awql.SelectStmt), "report", opts)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%d. Expected an error with %q, received %q", i, tt.fq, q)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%d. Expected no error with %q, received %v", i, tt.fq, err)
+		}
+		if q != tt.tq {
+			t.Errorf("%d. Expected %q with %q, received %q", i, tt.tq, tt.fq, q)
+		}
+		if !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("%d. Expected arguments %v with %q, received %v", i, tt.args, tt.fq, args)
+		}
+	}
+}
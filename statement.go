@@ -1,6 +1,11 @@
 package awqlparse
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
 
 // Field is the interface that must be implemented by a column.
 type Field interface {
@@ -54,10 +59,13 @@ func (c *ColumnPosition) Position() int {
 }
 
 // DynamicField is the interface that must be implemented by a query's field.
+// The second parameter of Alias indicates if the field has one.
+// Aggregate returns the aggregate function applied on the field, if it applies only
+// on its distinct values, and whether a function is used.
 type DynamicField interface {
-	Field
-	UseFunction() (string, bool)
-	Distinct() bool
+	Name() string
+	Alias() (string, bool)
+	Aggregate() (method string, distinct, ok bool)
 }
 
 // DynamicColumn represents a field.
@@ -73,6 +81,18 @@ func NewDynamicColumn(col *Column, method string, unique bool) *DynamicColumn {
 	return &DynamicColumn{Column: col, Method: method, Unique: unique}
 }
 
+// Alias returns the column alias.
+// The second parameter indicates if the column has one.
+func (c *DynamicColumn) Alias() (string, bool) {
+	return c.ColumnAlias, c.ColumnAlias != ""
+}
+
+// Aggregate returns the aggregate function applied on the column, if it applies only
+// on its distinct values, and whether a function is used.
+func (c *DynamicColumn) Aggregate() (method string, distinct, ok bool) {
+	return c.Method, c.Method != "" && c.Unique, c.Method != ""
+}
+
 // UseFunction returns the name of the method to apply of the column.
 // The second parameter indicates if a method is used.
 func (c *DynamicColumn) UseFunction() (string, bool) {
@@ -84,11 +104,81 @@ func (c *DynamicColumn) Distinct() bool {
 	return c.Unique
 }
 
+// LegacyField is the interface of the query's fields before DynamicField exposes the aggregates.
+type LegacyField interface {
+	Field
+	UseFunction() (string, bool)
+	Distinct() bool
+}
+
+// legacyField adapts a LegacyField to the DynamicField interface.
+type legacyField struct {
+	LegacyField
+}
+
+// Alias returns the field alias and whether it has one.
+func (f legacyField) Alias() (string, bool) {
+	alias := f.LegacyField.Alias()
+	return alias, alias != ""
+}
+
+// Aggregate returns the aggregate function of the field, if it applies only
+// on its distinct values, and whether a function is used.
+func (f legacyField) Aggregate() (method string, distinct, ok bool) {
+	if method, ok = f.UseFunction(); ok {
+		distinct = f.Distinct()
+	}
+	return
+}
+
+// FromLegacyField returns the field as a DynamicField.
+func FromLegacyField(f LegacyField) DynamicField {
+	if c, ok := f.(dynamicField); ok {
+		return c.DynamicField
+	}
+	return legacyField{f}
+}
+
+// dynamicField adapts a DynamicField to the LegacyField interface.
+type dynamicField struct {
+	DynamicField
+}
+
+// Alias returns the field alias.
+func (f dynamicField) Alias() string {
+	alias, _ := f.DynamicField.Alias()
+	return alias
+}
+
+// UseFunction returns the name of the aggregate function of the field.
+// The second parameter indicates if a function is used.
+func (f dynamicField) UseFunction() (string, bool) {
+	method, _, ok := f.Aggregate()
+	return method, ok
+}
+
+// Distinct returns true if the aggregate function applies only on the distinct values.
+func (f dynamicField) Distinct() bool {
+	_, distinct, _ := f.Aggregate()
+	return distinct
+}
+
+// ToLegacyField returns the field as a LegacyField.
+func ToLegacyField(f DynamicField) LegacyField {
+	if c, ok := f.(legacyField); ok {
+		return c.LegacyField
+	}
+	return dynamicField{f}
+}
+
 // Condition is the interface that must be implemented by a condition.
 type Condition interface {
 	Field
 	Operator() string
+	OperatorToken() Token
 	Value() (value []string, literal bool)
+	BoolValue() (bool, error)
+	Match(value string) (bool, error)
 }
 
 // Where represents a condition in where clause.
@@ -105,27 +195,50 @@ func (c *Where) Operator() string {
 	return c.Sign
 }
 
+// OperatorToken returns the token of the condition's operator, or ILLEGAL if it is unknown.
+func (c *Where) OperatorToken() Token {
+	return operatorToken(c.Sign)
+}
+
 // Value returns the column's value of the condition.
 func (c *Where) Value() ([]string, bool) {
 	return c.ColumnValue, c.IsValueLiteral
 }
 
+// BoolValue returns the value of the condition as a boolean.
+// It returns an error if the value is not the TRUE or FALSE literal.
+func (c *Where) BoolValue() (bool, error) {
+	if len(c.ColumnValue) != 1 || !c.IsValueLiteral || !isBoolLiteral(c.ColumnValue[0]) {
+		return false, NewXParserError(ErrMsgBadBool, strings.Join(c.ColumnValue, ","))
+	}
+	return boolLiterals[strings.ToUpper(c.ColumnValue[0])], nil
+}
+
 // Pattern represents a LIKE clause.
+// Expr is only used when the expression has a wildcard inside it, and keeps it as is.
+// Its segments, the texts between the wildcards, are given by the Segments method.
 type Pattern struct {
 	Equal, Prefix, Contains, Suffix string
+	Expr                            string
 }
 
 // Orderer is the interface that must be implemented by an ordering.
 type Orderer interface {
 	FieldPosition
 	SortDescending() bool
+	ExplicitAsc() bool
+	OrderIndex() int
 }
 
 // Order represents an order by clause.
+// SortAsc is true if the ascending order is explicitly asked with the ASC keyword.
+// Index is the rank of the order in the clause, starting at 0.
 // It implements the Orderer interface.
 type Order struct {
 	*ColumnPosition
 	SortDesc bool
+	SortAsc  bool
+	Index    int
 }
 
 // SortDescending returns true if the column needs to be sort by desc.
@@ -133,27 +246,50 @@ func (o *Order) SortDescending() bool {
 	return o.SortDesc
 }
 
+// ExplicitAsc returns true if the ASC keyword is used to sort the column.
+func (o *Order) ExplicitAsc() bool {
+	return o.SortAsc
+}
+
+// OrderIndex returns the rank of the order in the order by clause, starting at 0.
+func (o *Order) OrderIndex() int {
+	return o.Index
+}
+
 // Limit represents a limit clause.
+// WithRowCount is true if the clause is used, WithOffset if the offset is explicit, even zero.
 type Limit struct {
 	Offset, RowCount int
 	WithRowCount     bool
+	WithOffset       bool
 }
 
 // Stmt formats the query output.
 type Stmt interface {
+	Kind() Kind
+	Terminator() Terminator
 	VerticalOutput() bool
+	FullString() string
+	PrettyString(opts FormatOptions) string
+	Literals() []Literal
+	Redacted() string
 	fmt.Stringer
 }
 
 // Statement enables to format the query output.
+// End is the way the statement ends.
+//
+// Deprecated: GModifier is only kept for compatibility and is true if the statement ends
+// with the G modifier. The parser sets it with End, use Terminator or VerticalOutput instead.
 type Statement struct {
+	End       Terminator
 	GModifier bool
 }
 
 // VerticalOutput returns true if the G modifier is required.
 // It implements the Stmt interface.
 func (s Statement) VerticalOutput() bool {
-	return s.GModifier
+	return s.Terminator() == TerminatorG
 }
 
 // DataStmt represents a AWQL base statement.
@@ -183,25 +319,35 @@ func (s DataStatement) SourceName() string {
 	return s.TableName
 }
 
+// SetColumns replaces the list of table fields.
+func (s *DataStatement) SetColumns(fields ...DynamicField) {
+	s.Fields = fields
+}
+
+// SetSourceName changes the table's name.
+func (s *DataStatement) SetSourceName(name string) {
+	s.TableName = name
+}
+
 /*
 SelectStmt exposes the interface of AWQL Select Statement
 
 This is a extended version of the original grammar in order to manage all
 the possibilities of the AWQL command line tool.
 
-SelectClause     : SELECT ColumnList
+SelectClause     : SELECT DISTINCT? (ColumnList | * (EXCEPT (ColumnList))?)
 FromClause       : FROM SourceName
 WhereClause      : WHERE ConditionList
 DuringClause     : DURING DateRange
-GroupByClause    : GROUP BY Grouping (, Grouping)*
+GroupByClause    : GROUP BY Grouping (, Grouping)* (WITH ROLLUP)?
 OrderByClause    : ORDER BY Order (, Order)*
 LimitClause      : LIMIT StartIndex , PageSize
 
 ConditionList    : Condition (AND Condition)*
 Condition        : ColumnName Operator Value
-Value            : ValueLiteral | String | ValueLiteralList | StringList
+Value            : ValueLiteral | BoolLiteral | Placeholder | String | ValueLiteralList | StringList
 Order         : ColumnName (DESC | ASC)?
-DateRange        : DateRangeLiteral | Date,Date
+DateRange        : DateRangeLiteral | Date,Date | NamedParameter (, NamedParameter)?
 ColumnList       : ColumnName (, ColumnName)*
 ColumnName       : Literal
 TableName        : Literal
@@ -213,33 +359,55 @@ Operator         : = | != | > | >= | < | <= | IN | NOT_IN | STARTS_WITH | STARTS
 String           : StringSingleQ | StringDoubleQ
 StringSingleQ    : '(char)'
 StringDoubleQ    : "(char)"
-StringList       : [ String (, String)* ]
+StringList       : [ String (, String)* ,? ]
 ValueLiteral     : [a-zA-Z0-9_.]*
-ValueLiteralList : [ ValueLiteral (, ValueLiteral)* ]
+BoolLiteral      : TRUE | FALSE
+Placeholder      : ? | NamedParameter
+NamedParameter   : @Literal | :Literal
+ValueLiteralList : [ (ValueLiteral | Placeholder) (, (ValueLiteral | Placeholder))* ,? ]
 Literal          : [a-zA-Z0-9_]*
 DateRangeLiteral : TODAY | YESTERDAY | LAST_7_DAYS | THIS_WEEK_SUN_TODAY | THIS_WEEK_MON_TODAY | LAST_WEEK |
-									 LAST_14_DAYS | LAST_30_DAYS | LAST_BUSINESS_WEEK | LAST_WEEK_SUN_SAT | THIS_MONTH
+									 LAST_14_DAYS | LAST_30_DAYS | LAST_90_DAYS | LAST_BUSINESS_WEEK | LAST_WEEK_SUN_SAT |
+									 THIS_MONTH | LAST_MONTH | ALL_TIME
 Date             : 8-digit integer: YYYYMMDD
 */
 type SelectStmt interface {
 	DataStmt
 	ConditionList() []Condition
 	DuringList() []string
+	DuringImplied() bool
 	GroupList() []FieldPosition
+	WithRollup() bool
 	OrderList() []Orderer
+	Distinct() bool
+	ExcludedList() []Field
 	StartIndex() int
 	PageSize() (int, bool)
+	LimitRange() (offset, count int, ok bool)
 	LegacyString() string
+	Legalize() (SelectStmt, []ClauseChange)
+	Normalize() string
+	Fingerprint() string
+	DownloadRequest(format string) (url.Values, error)
+	MatchRow(row map[string]string) (bool, error)
+	DuringRange(now time.Time) (start, end time.Time, err error)
+	OutputColumns() []OutputColumn
 }
 
 // SelectStatement represents a AWQL SELECT statement.
-// SELECT...FROM...WHERE...DURING...GROUP BY...ORDER BY...LIMIT...
+// SELECT...EXCEPT...FROM...WHERE...DURING...GROUP BY...ORDER BY...LIMIT...
 // It implements the SelectStmt interface.
 type SelectStatement struct {
 	DataStatement
-	Where   []Condition
-	During  []string
-	GroupBy []FieldPosition
+	Unique   bool
+	Excluded []Field
+	Where    []Condition
+	During   []string
+	// ImpliedDuring is true if the date range is the default one of the parser.
+	ImpliedDuring bool
+	GroupBy       []FieldPosition
+	// Rollup is true if the subtotal rows of the groups are asked with WITH ROLLUP.
+	Rollup  bool
 	OrderBy []Orderer
 	Limit
 }
@@ -254,24 +422,150 @@ func (s SelectStatement) DuringList() []string {
 	return s.During
 }
 
+// DuringImplied returns true if the date range is not given by the query
+// but set by default by the parser.
+func (s SelectStatement) DuringImplied() bool {
+	return s.ImpliedDuring
+}
+
 // GroupList returns the group by columns.
 func (s SelectStatement) GroupList() []FieldPosition {
 	return s.GroupBy
 }
 
+// WithRollup returns true if the subtotal rows of the groups are asked.
+func (s SelectStatement) WithRollup() bool {
+	return s.Rollup
+}
+
 // OrderList returns the order by columns.
 func (s SelectStatement) OrderList() []Orderer {
 	return s.OrderBy
 }
 
-// StartIndex returns the start index.
+// Distinct returns true if only the distinct rows are selected.
+func (s SelectStatement) Distinct() bool {
+	return s.Unique
+}
+
+// ExcludedList returns the columns excluded from the rune '*'.
+func (s SelectStatement) ExcludedList() []Field {
+	return s.Excluded
+}
+
+// StartIndex returns the start index, zero without limit clause.
 func (s SelectStatement) StartIndex() int {
-	return s.Offset
+	offset, _, _ := s.LimitRange()
+	return offset
 }
 
-// PageSize returns the row count.
+// PageSize returns the row count and whether the limit clause is used.
 func (s SelectStatement) PageSize() (int, bool) {
-	return s.RowCount, s.WithRowCount
+	_, count, ok := s.LimitRange()
+	return count, ok
+}
+
+// LimitRange returns the start index and the row count of the limit clause,
+// and whether it is used. LIMIT 0 returns (0, 0, true), no limit (0, 0, false).
+func (s SelectStatement) LimitRange() (offset, count int, ok bool) {
+	if !s.WithRowCount {
+		return 0, 0, false
+	}
+	return s.Offset, s.RowCount, true
+}
+
+// AndWhere adds a condition to the where clause.
+// Literal values are used without quotes, as numbers or enums.
+// The operator is stored in its canonical form, whatever its case.
+// As with the parser, the ISO dates compared to the Date column are converted to YYYYMMDD.
+func (s *SelectStatement) AndWhere(column, operator string, values []string, literal bool) error {
+	if !isIdentifier(column) {
+		return NewXParserError(ErrMsgBadField, column)
+	}
+	if !isOperatorString(operator) || len(values) == 0 {
+		return NewXParserError(ErrMsgSyntax, operator)
+	}
+	op := operatorToken(operator)
+	if len(values) > 1 && !isListOperator(op) {
+		return NewXParserError(ErrMsgOperatorValue, operators[op]+" expects a single value")
+	}
+	// Copy of the values, the caller keeps its list.
+	values = append([]string(nil), values...)
+	if literal {
+		for i, v := range values {
+			if !isValueLiteralString(v) {
+				return NewXParserError(ErrMsgSyntax, v)
+			}
+			values[i] = normalizeValue(v)
+		}
+	}
+	cond := &Where{
+		Column:         NewColumn(column, ""),
+		Sign:           operators[op],
+		ColumnValue:    values,
+		IsValueLiteral: literal,
+	}
+	if !literal && column == "Date" {
+		if err := normalizeISODates(cond); err != nil {
+			return err
+		}
+	}
+	s.Where = append(s.Where, cond)
+	return nil
+}
+
+// SetDuring replaces the date range by a date range literal or two dates formatted as YYYYMMDD.
+// Without date, the during clause is removed.
+// Either way, the date range is no more the implied one.
+func (s *SelectStatement) SetDuring(dates ...string) error {
+	if err := checkDuring(dates); err != nil {
+		return err
+	}
+	if len(dates) == 1 {
+		// Stores the canonical form of the date range literal.
+		dates = []string{strings.ToUpper(dates[0])}
+	}
+	s.During, s.ImpliedDuring = dates, false
+	return nil
+}
+
+// AddOrderBy adds a column to sort on, with its name, alias or position in the selected fields.
+// An error is returned if the column is already used to sort.
+func (s *SelectStatement) AddOrderBy(expr string, desc bool) error {
+	column, err := s.searchColumn(expr)
+	if err != nil {
+		return err
+	}
+	if s.sortedBy(column.Position()) {
+		return NewXParserError(ErrMsgDuplicateOrder, expr)
+	}
+	s.OrderBy = append(s.OrderBy, &Order{ColumnPosition: column, SortDesc: desc, Index: len(s.OrderBy)})
+	return nil
+}
+
+// sortedBy returns true if the field at this position is already used to sort.
+func (s SelectStatement) sortedBy(pos int) bool {
+	for _, o := range s.OrderBy {
+		if o.Position() == pos {
+			return true
+		}
+	}
+	return false
+}
+
+// SetLimit sets the start index and the row count of the limit clause.
+func (s *SelectStatement) SetLimit(offset, count int) error {
+	if offset < 0 {
+		return NewXParserError(ErrMsgBadLimit, offset)
+	}
+	if count < 0 {
+		return NewXParserError(ErrMsgBadLimit, count)
+	}
+	s.Offset = offset
+	s.RowCount = count
+	s.WithRowCount = true
+	s.WithOffset = offset > 0
+	return nil
 }
 
 /*
@@ -287,6 +581,13 @@ type CreateViewStmt interface {
 	DataStmt
 	ReplaceMode() bool
 	SourceQuery() SelectStmt
+	ColumnsMapping() ([]ColumnMap, error)
+}
+
+// ColumnMap pairs a column declared by a view with the field of its source query.
+type ColumnMap struct {
+	Column DynamicField
+	Field  DynamicField
 }
 
 // CreateViewStatement represents a AWQL CREATE VIEW statement.
@@ -308,6 +609,23 @@ func (s CreateViewStatement) SourceQuery() SelectStmt {
 	return s.View
 }
 
+// ColumnsMapping returns each declared column of the view with the field
+// at the same position in the source query.
+// An error is returned if no column is declared or if their number does not match.
+func (s CreateViewStatement) ColumnsMapping() ([]ColumnMap, error) {
+	if len(s.Fields) == 0 {
+		return nil, NewParserError(ErrMsgMissingColumns)
+	}
+	if s.View == nil || len(s.Fields) != len(s.View.Fields) {
+		return nil, NewParserError(ErrMsgColumnsNotMatch)
+	}
+	list := make([]ColumnMap, len(s.Fields))
+	for i, c := range s.Fields {
+		list[i] = ColumnMap{Column: c, Field: s.View.Fields[i]}
+	}
+	return list, nil
+}
+
 // FullStmt proposes the full statement mode.
 type FullStmt interface {
 	FullMode() bool
@@ -330,7 +648,7 @@ DescribeStmt exposes the interface of AWQL Describe Statement
 Not supported natively by Adwords API. Used by the following AWQL command line tool:
 https://github.com/rvflash/awql/
 
-DescribeClause   : (DESCRIBE | DESC) (FULL)* SourceName (ColumnName)*
+DescribeClause   : (DESCRIBE | DESC) (FULL)* (SourceName (ColumnName)* | SourceName.ColumnName)
 */
 type DescribeStmt interface {
 	DataStmt
@@ -386,6 +704,8 @@ func (s ShowStatement) LikePattern() (Pattern, bool) {
 		used = true
 	case s.Like.Suffix != "":
 		used = true
+	case s.Like.Expr != "":
+		used = true
 	}
 	return s.Like, used
 }
@@ -394,3 +714,30 @@ func (s ShowStatement) LikePattern() (Pattern, bool) {
 func (s ShowStatement) WithFieldName() (string, bool) {
 	return s.With, s.UseWith
 }
+
+/*
+UseStmt exposes the interface of AWQL Use Statement
+
+Not supported natively by Adwords API. Used by the following AWQL command line tool:
+https://github.com/rvflash/awql/
+
+UseClause   : USE ClientCustomerId
+ClientCustomerId : String | Digit (-Digit)*
+*/
+type UseStmt interface {
+	AccountID() string
+	Stmt
+}
+
+// UseStatement represents a AWQL USE statement.
+// USE...
+// It implements the UseStmt interface.
+type UseStatement struct {
+	Account string
+	Statement
+}
+
+// AccountID returns the client customer ID to use.
+func (s UseStatement) AccountID() string {
+	return s.Account
+}
@@ -0,0 +1,285 @@
+package awqlparse_test
+
+import (
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestDataStatement_SetSourceName(t *testing.T) {
+	q := `SELECT CampaignId FROM CAMPAIGN_PERFORMANCE_REPORT`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	stmt := stmts[0].(*awql.SelectStatement)
+	stmt.SetSourceName("ADGROUP_PERFORMANCE_REPORT")
+	if name := stmts[0].(awql.SelectStmt).SourceName(); name != "ADGROUP_PERFORMANCE_REPORT" {
+		t.Errorf("Expected ADGROUP_PERFORMANCE_REPORT as source name, received %q", name)
+	}
+	if s := stmt.String(); s != "SELECT CampaignId FROM ADGROUP_PERFORMANCE_REPORT" {
+		t.Errorf("Unexpected statement after the change of source, received %q", s)
+	}
+}
+
+func TestDataStatement_SetColumns(t *testing.T) {
+	stmt := &awql.DescribeStatement{}
+	stmt.SetSourceName("CAMPAIGN_PERFORMANCE_REPORT")
+	stmt.SetColumns(awql.NewDynamicColumn(awql.NewColumn("CampaignId", ""), "", false))
+	if cols := stmt.Columns(); len(cols) != 1 || cols[0].Name() != "CampaignId" {
+		t.Errorf("Expected CampaignId as only column, received %v", cols)
+	}
+	if s := stmt.String(); s != "DESC CAMPAIGN_PERFORMANCE_REPORT CampaignId" {
+		t.Errorf("Unexpected statement after the change of columns, received %q", s)
+	}
+	stmt.SetColumns()
+	if cols := stmt.Columns(); len(cols) != 0 {
+		t.Errorf("Expected no column, received %v", cols)
+	}
+}
+
+func TestSelectStatement_Mutators(t *testing.T) {
+	q := `SELECT CampaignId, CampaignName AS name, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Clicks > 0`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	stmt := stmts[0].(*awql.SelectStatement)
+
+	values := []string{"rv"}
+	if err := stmt.AndWhere("AccountDescriptiveName", "=", values, false); err != nil {
+		t.Errorf("Expected no error with a valid condition, received %v", err)
+	}
+	// The statement keeps its own copy of the values.
+	values[0] = "changed"
+	if err := stmt.AndWhere("CampaignId", "IN", []string{"1", "2"}, true); err != nil {
+		t.Errorf("Expected no error with a valid literal condition, received %v", err)
+	}
+	if err := stmt.SetDuring("20161224", "20161225"); err != nil {
+		t.Errorf("Expected no error with a valid date range, received %v", err)
+	}
+	if err := stmt.AddOrderBy("name", true); err != nil {
+		t.Errorf("Expected no error with an alias, received %v", err)
+	}
+	if err := stmt.AddOrderBy("3", false); err != nil {
+		t.Errorf("Expected no error with a position, received %v", err)
+	}
+	if err := stmt.SetLimit(5, 10); err != nil {
+		t.Errorf("Expected no error with a valid limit, received %v", err)
+	}
+	expected := "SELECT CampaignId, CampaignName AS name, Clicks FROM CAMPAIGN_PERFORMANCE_REPORT " +
+		"WHERE Clicks > 0 AND AccountDescriptiveName = 'rv' AND CampaignId IN [ 1 , 2 ] " +
+		"DURING 20161224,20161225 ORDER BY 2 DESC, 3 LIMIT 5, 10"
+	if s := stmt.String(); s != expected {
+		t.Errorf("Expected %q, received %q", expected, s)
+	}
+
+	// Invalid changes return an error and keep the statement as is.
+	if err := stmt.AndWhere("Clicks", "LIKE", []string{"1"}, true); err == nil {
+		t.Error("Expected an error with an unknown operator")
+	}
+	if err := stmt.AndWhere("Clicks", ">", []string{"'1'"}, true); err == nil {
+		t.Error("Expected an error with a string as literal value")
+	}
+	if err := stmt.AndWhere("Clicks", ">", nil, false); err == nil {
+		t.Error("Expected an error without value")
+	}
+	if err := stmt.SetDuring("YESTERDAY", "TODAY"); err == nil {
+		t.Error("Expected an error with two date range literals")
+	}
+	if err := stmt.SetDuring("20161224"); err == nil {
+		t.Error("Expected an error with only one date")
+	}
+	if err := stmt.AddOrderBy("Cost", false); err == nil {
+		t.Error("Expected an error with an unknown column")
+	}
+	if err := stmt.SetLimit(-1, 10); err == nil {
+		t.Error("Expected an error with a negative offset")
+	}
+	if s := stmt.String(); s != expected {
+		t.Errorf("Expected %q, received %q", expected, s)
+	}
+}
+
+func TestCreateViewStatement_ColumnsMapping(t *testing.T) {
+	q := `CREATE VIEW rv (Day, Spend) AS SELECT Date, SUM(Cost) FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1`
+	stmt, err := awql.ParseCreateViewString(q)
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	list, err := stmt.ColumnsMapping()
+	if err != nil {
+		t.Fatalf("Expected no error with the mapping of %q, received %v", q, err)
+	}
+	var tests = []struct {
+		column, field, method string
+	}{
+		{column: "Day", field: "Date"},
+		{column: "Spend", field: "Cost", method: "SUM"},
+	}
+	if len(list) != len(tests) {
+		t.Fatalf("Expected %d columns, received %v", len(tests), list)
+	}
+	for i, tt := range tests {
+		if name := list[i].Column.Name(); name != tt.column {
+			t.Errorf("%d. Expected the column %v, received %v", i, tt.column, name)
+		}
+		if name := list[i].Field.Name(); name != tt.field {
+			t.Errorf("%d. Expected the field %v, received %v", i, tt.field, name)
+		}
+		if method, _, _ := list[i].Field.Aggregate(); method != tt.method {
+			t.Errorf("%d. Expected the function %q, received %q", i, tt.method, method)
+		}
+	}
+
+	// Without declared columns.
+	stmt, _ = awql.ParseCreateViewString(`CREATE VIEW rv AS SELECT Date FROM CAMPAIGN_PERFORMANCE_REPORT`)
+	if _, err := stmt.ColumnsMapping(); err == nil {
+		t.Error("Expected an error without declared columns")
+	}
+	// With columns added after the parse.
+	view := stmt.(*awql.CreateViewStatement)
+	view.SetColumns(awql.NewDynamicColumn(awql.NewColumn("Day", ""), "", false), awql.NewDynamicColumn(awql.NewColumn("Spend", ""), "", false))
+	if _, err := view.ColumnsMapping(); err == nil {
+		t.Error("Expected an error when the number of columns does not match")
+	}
+}
+
+// field implements the LegacyField interface.
+type field struct {
+	name, method string
+}
+
+func (f field) Name() string                { return f.name }
+func (f field) Alias() string               { return "" }
+func (f field) UseFunction() (string, bool) { return f.method, f.method != "" }
+func (f field) Distinct() bool              { return true }
+
+func TestDynamicField_Aggregate(t *testing.T) {
+	stmt, err := awql.ParseSelectString("SELECT CampaignName, COUNT(*) AS total, SUM(DISTINCT Cost) FROM CAMPAIGN_PERFORMANCE_REPORT GROUP BY 1")
+	if err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	var tests = []struct {
+		f            awql.DynamicField
+		method       string
+		distinct, ok bool
+		name, alias  string
+	}{
+		{f: stmt.Columns()[0], name: "CampaignName"},
+		{f: stmt.Columns()[1], method: "COUNT", ok: true, name: "*", alias: "total"},
+		{f: stmt.Columns()[2], method: "SUM", distinct: true, ok: true, name: "Cost"},
+		{f: awql.FromLegacyField(field{name: "Cost", method: "MAX"}), method: "MAX", distinct: true, ok: true, name: "Cost"},
+		{f: awql.FromLegacyField(field{name: "Cost"}), name: "Cost"},
+	}
+	for i, tt := range tests {
+		method, distinct, ok := tt.f.Aggregate()
+		if method != tt.method || distinct != tt.distinct || ok != tt.ok {
+			t.Errorf("%d. Expected %q, %v, %v, received %q, %v, %v", i, tt.method, tt.distinct, tt.ok, method, distinct, ok)
+		}
+		if alias, ok := tt.f.Alias(); tt.f.Name() != tt.name || alias != tt.alias || ok != (tt.alias != "") {
+			t.Errorf("%d. Expected %v AS %v, received %v AS %v", i, tt.name, tt.alias, tt.f.Name(), alias)
+		}
+		// The legacy interface gives the same information.
+		l := awql.ToLegacyField(tt.f)
+		if m, ok := l.UseFunction(); m != tt.method || ok != tt.ok || l.Alias() != tt.alias {
+			t.Errorf("%d. Expected %q AS %v with the legacy field, received %q AS %v", i, tt.method, tt.alias, m, l.Alias())
+		}
+		if f := awql.FromLegacyField(l); f != tt.f {
+			t.Errorf("%d. Expected the adapted field to be unwrapped, received %v", i, f)
+		}
+	}
+}
+
+func TestWhere_BoolValue(t *testing.T) {
+	var tests = []struct {
+		q        string
+		val, err bool
+	}{
+		{q: `SELECT Criteria FROM R WHERE IsNegative = TRUE`, val: true},
+		{q: `SELECT Criteria FROM R WHERE IsNegative = false`},
+		{q: `SELECT Criteria FROM R WHERE IsNegative = "true"`, err: true},
+		{q: `SELECT Criteria FROM R WHERE Clicks = 1`, err: true},
+		{q: `SELECT Criteria FROM R WHERE IsNegative IN [TRUE, FALSE]`, err: true},
+	}
+	for i, tt := range tests {
+		stmt, err := awql.ParseSelectString(tt.q)
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		}
+		val, err := stmt.ConditionList()[0].BoolValue()
+		if (err != nil) != tt.err {
+			t.Errorf("%d. Expected error %v with %q, received %v", i, tt.err, tt.q, err)
+		} else if val != tt.val {
+			t.Errorf("%d. Expected %v with %q, received %v", i, tt.val, tt.q, val)
+		}
+	}
+}
+
+func TestWhere_OperatorToken(t *testing.T) {
+	var tests = []struct {
+		q, op string
+		tk    awql.Token
+	}{
+		{q: `SELECT Criteria FROM R WHERE Criteria contains_ignore_case "rv"`, op: "CONTAINS_IGNORE_CASE", tk: awql.CONTAINS_IGNORE_CASE},
+		{q: `SELECT Criteria FROM R WHERE Id not_in [1, 2]`, op: "NOT_IN", tk: awql.NOT_IN},
+		{q: `SELECT Criteria FROM R WHERE Id >= 1`, op: ">=", tk: awql.SUPERIOR_OR_EQUAL},
+	}
+	for i, tt := range tests {
+		stmt, err := awql.ParseSelectString(tt.q)
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		}
+		c := stmt.ConditionList()[0]
+		if op := c.Operator(); op != tt.op {
+			t.Errorf("%d. Expected the operator %q, received %q", i, tt.op, op)
+		}
+		if tk := c.OperatorToken(); tk != tt.tk {
+			t.Errorf("%d. Expected the token %v, received %v", i, tt.tk, tk)
+		}
+	}
+	s := &awql.SelectStatement{}
+	if err := s.AndWhere("Name", "starts_with", []string{"rv"}, false); err != nil {
+		t.Fatalf("Expected no error, received %v", err)
+	}
+	if op := s.ConditionList()[0].Operator(); op != "STARTS_WITH" {
+		t.Errorf("Expected the canonical operator, received %q", op)
+	}
+	if tk := (&awql.Where{Sign: "~"}).OperatorToken(); tk != awql.ILLEGAL {
+		t.Errorf("Expected an illegal operator, received %v", tk)
+	}
+}
+
+func TestSelectStatement_LimitRange(t *testing.T) {
+	var tests = []struct {
+		q, s          string
+		offset, count int
+		ok            bool
+	}{
+		{q: `SELECT Cost FROM R`},
+		{q: `SELECT Cost FROM R LIMIT 0`, ok: true},
+		{q: `SELECT Cost FROM R LIMIT 0, 0`, ok: true},
+		{q: `SELECT Cost FROM R LIMIT 0, 5`, count: 5, ok: true},
+		{q: `SELECT Cost FROM R LIMIT 10,5`, s: `SELECT Cost FROM R LIMIT 10, 5`, offset: 10, count: 5, ok: true},
+	}
+	for i, tt := range tests {
+		stmt, err := awql.ParseSelectString(tt.q)
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		}
+		offset, count, ok := stmt.LimitRange()
+		if offset != tt.offset || count != tt.count || ok != tt.ok {
+			t.Errorf("%d. Expected (%d, %d, %v), received (%d, %d, %v)", i, tt.offset, tt.count, tt.ok, offset, count, ok)
+		}
+		if size, used := stmt.PageSize(); size != tt.count || used != tt.ok || stmt.StartIndex() != tt.offset {
+			t.Errorf("%d. Expected the same values with PageSize and StartIndex", i)
+		}
+		if tt.s == "" {
+			tt.s = tt.q
+		}
+		if s := stmt.String(); s != tt.s {
+			t.Errorf("%d. Expected %q, received %q", i, tt.s, s)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package awqlparse
+
+import "time"
+
+// Stats represents the size and the complexity of a statement,
+// to reject the too expensive ones before their execution.
+type Stats struct {
+	Fields      int  // number of selected fields
+	Conditions  int  // number of conditions
+	Values      int  // number of values of the conditions
+	MaxListSize int  // number of values of the largest list
+	DuringDays  int  // number of days of the date range, 0 if it is not resolvable, -1 for ALL_TIME
+	Aggregate   bool // true if an aggregate function is used
+	Group       bool // true if the rows are grouped
+	Order       bool // true if the rows are sorted
+	Depth       int  // nesting depth of the select statements
+}
+
+// StatsOf returns the metrics of the statement.
+// The date range literals are resolved relatively to now.
+// The select statement used as source of a view is measured with a depth of 1.
+func StatsOf(stmt Stmt, now time.Time) (st Stats) {
+	if s, ok := stmt.(CreateViewStmt); ok {
+		if v := s.SourceQuery(); !isNilSelect(v) {
+			stmt = v
+		}
+	}
+	s, ok := stmt.(SelectStmt)
+	if !ok {
+		if d, ok := stmt.(DataStmt); ok {
+			st.Fields = len(d.Columns())
+		}
+		return
+	}
+	st.Depth = 1
+	st.Fields = len(s.Columns())
+	for _, f := range s.Columns() {
+		if _, _, ok := f.Aggregate(); ok {
+			st.Aggregate = true
+		}
+	}
+	st.Conditions = len(s.ConditionList())
+	for _, c := range s.ConditionList() {
+		val, _ := c.Value()
+		if st.Values += len(val); len(val) > st.MaxListSize && len(val) > 1 {
+			st.MaxListSize = len(val)
+		}
+	}
+	st.DuringDays = duringDays(s, now)
+	st.Group = len(s.GroupList()) > 0
+	st.Order = len(s.OrderList()) > 0
+	return
+}
+
+// duringDays returns the number of days of the date range of the statement,
+// 0 if it is not resolvable and -1 if it has no beginning.
+func duringDays(s SelectStmt, now time.Time) int {
+	start, end, err := s.DuringRange(now)
+	if err != nil {
+		return 0
+	}
+	if start.IsZero() {
+		return -1
+	}
+	// Rounds the duration to ignore the daylight saving time.
+	return int((end.Sub(start)+12*time.Hour)/(24*time.Hour)) + 1
+}
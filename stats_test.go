@@ -0,0 +1,60 @@
+package awqlparse_test
+
+import (
+	"testing"
+	"time"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestStatsOf(t *testing.T) {
+	now := time.Date(2016, 12, 24, 15, 0, 0, 0, time.UTC)
+	var tests = []struct {
+		q  string
+		st awql.Stats
+	}{
+		{
+			q:  `SELECT CampaignName, Cost FROM CAMPAIGN_PERFORMANCE_REPORT`,
+			st: awql.Stats{Fields: 2, Depth: 1},
+		},
+		{
+			q: `SELECT CampaignName, SUM(Cost) FROM CAMPAIGN_PERFORMANCE_REPORT ` +
+				`WHERE CampaignId IN [1, 2, 3] AND Clicks > 0 AND CampaignStatus IN ["ENABLED", "PAUSED"] ` +
+				`DURING LAST_7_DAYS GROUP BY 1 ORDER BY 2 DESC`,
+			st: awql.Stats{
+				Fields: 2, Conditions: 3, Values: 6, MaxListSize: 3, DuringDays: 7,
+				Aggregate: true, Group: true, Order: true, Depth: 1,
+			},
+		},
+		{
+			q:  `SELECT Cost FROM R DURING 20161201,20161231`,
+			st: awql.Stats{Fields: 1, DuringDays: 31, Depth: 1},
+		},
+		{
+			q:  `SELECT Cost FROM R DURING ALL_TIME`,
+			st: awql.Stats{Fields: 1, DuringDays: -1, Depth: 1},
+		},
+		{
+			q:  `SELECT Cost FROM R DURING @start, @end`,
+			st: awql.Stats{Fields: 1, Depth: 1},
+		},
+		{
+			q:  `CREATE VIEW V (Name) AS SELECT CampaignName FROM R WHERE Cost > 0 DURING TODAY`,
+			st: awql.Stats{Fields: 1, Conditions: 1, Values: 1, DuringDays: 1, Depth: 1},
+		},
+		{
+			q:  `DESC R CampaignName`,
+			st: awql.Stats{Fields: 1},
+		},
+		{q: `SHOW TABLES`},
+	}
+	for i, tt := range tests {
+		stmts, err := awql.ParseString(tt.q)
+		if err != nil {
+			t.Fatalf("%d. Expected no error with %q, received %v", i, tt.q, err)
+		}
+		if st := awql.StatsOf(stmts[0], now); st != tt.st {
+			t.Errorf("%d. Expected %+v with %q, received %+v", i, tt.st, tt.q, st)
+		}
+	}
+}
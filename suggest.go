@@ -0,0 +1,74 @@
+package awqlparse
+
+import "strings"
+
+// statementNames lists the keywords starting a statement.
+var statementNames = []string{"CREATE", "DESC", "DESCRIBE", "SELECT", "SHOW", "USE"}
+
+// writeVerbs lists the SQL verbs starting a statement that modifies data.
+// They are not reserved words, only recognized to explain that AWQL is read-only.
+var writeVerbs = map[string]bool{"ALTER": true, "DELETE": true, "INSERT": true, "TRUNCATE": true, "UPDATE": true}
+
+// suggest returns the candidate the closest to the word, or an empty string if none is close enough.
+// The comparison ignores the case. A candidate beginning with the word is used when no one is close.
+func suggest(word string, candidates []string) string {
+	word = strings.ToUpper(word)
+	if word == "" {
+		return ""
+	}
+	var hint, prefix string
+	best := len([]rune(word))/3 + 1
+	if best > 2 {
+		best = 2
+	}
+	for _, c := range candidates {
+		uc := strings.ToUpper(c)
+		if uc == word {
+			return ""
+		}
+		if d := levenshtein(word, uc); d <= best && (hint == "" || d < best) {
+			hint, best = c, d
+		}
+		if prefix == "" && len(word) > 1 && strings.HasPrefix(uc, word) {
+			prefix = c
+		}
+	}
+	if hint == "" {
+		return prefix
+	}
+	return hint
+}
+
+// levenshtein returns the number of runes to insert, delete or substitute to change a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cur := row[j]
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			row[j] = min3(row[j]+1, row[j-1]+1, prev+cost)
+			prev = cur
+		}
+	}
+	return row[len(rb)]
+}
+
+// min3 returns the smallest of the three integers.
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
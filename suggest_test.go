@@ -0,0 +1,38 @@
+package awqlparse_test
+
+import (
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestParserError_Hint(t *testing.T) {
+	var tests = []struct {
+		q, err, hint string
+	}{
+		{q: `SELECT SUMM(Cost) FROM CAMPAIGN_PERFORMANCE_REPORT`, err: "ParserError.INVALID_FUNCTION (SUMM), did you mean SUM?", hint: "SUM"},
+		{q: `SELECT mux(Cost) FROM CAMPAIGN_PERFORMANCE_REPORT`, err: "ParserError.INVALID_FUNCTION (mux), did you mean MAX?", hint: "MAX"},
+		{q: `SELECT rv(Cost) FROM CAMPAIGN_PERFORMANCE_REPORT`, err: "ParserError.INVALID_FUNCTION (rv)"},
+		{q: `SLECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT`, err: "ParserError.UNKNOWN_STATEMENT (SLECT) at line 1 of statement 1, did you mean SELECT?", hint: "SELECT"},
+		{q: `DELETE FROM CAMPAIGN_PERFORMANCE_REPORT`, err: "ParserError.UNSUPPORTED_STATEMENT (DELETE) at line 1 of statement 1: AWQL is read-only"},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT DURING YESTERDAI`, err: "ParserError.INVALID_DURING (YESTERDAI), did you mean YESTERDAY?", hint: "YESTERDAY"},
+		{q: `SELECT Cost FROM CAMPAIGN_PERFORMANCE_REPORT DURING last_7`, err: "ParserError.INVALID_DURING (last_7), did you mean LAST_7_DAYS?", hint: "LAST_7_DAYS"},
+		{q: `DESC FROM CAMPAIGN_PERFORMANCE_REPORT`, err: "ParserError.UNEXPECTED_FROM (FROM), did you mean DESC CAMPAIGN_PERFORMANCE_REPORT?", hint: "DESC CAMPAIGN_PERFORMANCE_REPORT"},
+	}
+	for i, tt := range tests {
+		_, err := awql.NewParser(strings.NewReader(tt.q)).Parse()
+		if err == nil {
+			t.Errorf("%d. Expected an error with %q", i, tt.q)
+			continue
+		}
+		if err.Error() != tt.err {
+			t.Errorf("%d. Expected the error %q, received %q", i, tt.err, err)
+		}
+		if pe, ok := err.(*awql.ParserError); !ok {
+			t.Errorf("%d. Expected a parser error, received %T", i, err)
+		} else if pe.Hint() != tt.hint {
+			t.Errorf("%d. Expected the hint %q, received %q", i, tt.hint, pe.Hint())
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package awqlparse
+
+import "strconv"
+
+// Terminator represents the way a statement ends.
+type Terminator int
+
+// List of the statement terminators.
+const (
+	// TerminatorEOF is used when the statement is not terminated, as at the end of the input.
+	TerminatorEOF Terminator = iota
+	// TerminatorSemicolon ends the statement with a semicolon, for a horizontal output.
+	TerminatorSemicolon
+	// TerminatorG ends the statement with the G modifier, for a vertical output.
+	TerminatorG
+)
+
+// terminatorNames lists the names of the statement terminators.
+var terminatorNames = [...]string{
+	TerminatorEOF:       "EOF",
+	TerminatorSemicolon: ";",
+	TerminatorG:         "\\G",
+}
+
+// String returns the name of the terminator.
+func (t Terminator) String() string {
+	if t >= 0 && int(t) < len(terminatorNames) {
+		return terminatorNames[t]
+	}
+	return "Terminator(" + strconv.Itoa(int(t)) + ")"
+}
+
+// Terminator returns the way the statement ends.
+// A statement only flagged with the deprecated GModifier ends with the G modifier.
+// It implements the Stmt interface.
+func (s Statement) Terminator() Terminator {
+	if s.End == TerminatorEOF && s.GModifier {
+		return TerminatorG
+	}
+	return s.End
+}
+
+// terminate sets the way the statement ends, with the deprecated GModifier in sync.
+func (s *Statement) terminate(t Terminator) {
+	s.End, s.GModifier = t, t == TerminatorG
+}
+
+// Terminator returns the way the statement ends, that is the end of its view.
+func (s CreateViewStatement) Terminator() Terminator {
+	if s.View == nil {
+		return s.Statement.Terminator()
+	}
+	return s.View.Terminator()
+}
+
+// VerticalOutput returns true if the view ends with the G modifier.
+func (s CreateViewStatement) VerticalOutput() bool {
+	return s.Terminator() == TerminatorG
+}
+
+// suffix outputs the terminator as written at the end of a statement, nothing without one.
+func (t Terminator) suffix() string {
+	switch t {
+	case TerminatorSemicolon:
+		return ";"
+	case TerminatorG:
+		return "\\G"
+	}
+	return ""
+}
+
+// FullString outputs the create view statement followed by its terminator.
+// Parsing it gives the same statement.
+func (s CreateViewStatement) FullString() string {
+	return s.String() + s.Terminator().suffix()
+}
+
+// FullString outputs the describe statement followed by its terminator.
+// Parsing it gives the same statement.
+func (s DescribeStatement) FullString() string {
+	return s.String() + s.Terminator().suffix()
+}
+
+// FullString outputs the select statement with all its clauses followed by its terminator,
+// as the G modifier. Parsing it gives the same statement.
+func (s SelectStatement) FullString() string {
+	return s.String() + s.Terminator().suffix()
+}
+
+// FullString outputs the show statement followed by its terminator.
+// Parsing it gives the same statement.
+func (s ShowStatement) FullString() string {
+	return s.String() + s.Terminator().suffix()
+}
+
+// FullString outputs the use statement followed by its terminator.
+// Parsing it gives the same statement.
+func (s UseStatement) FullString() string {
+	return s.String() + s.Terminator().suffix()
+}
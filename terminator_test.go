@@ -0,0 +1,71 @@
+package awqlparse_test
+
+import (
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestStmt_Terminator(t *testing.T) {
+	var queries = []string{
+		`SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT`,
+		`DESC CAMPAIGN_PERFORMANCE_REPORT`,
+		`SHOW TABLES`,
+		`CREATE VIEW rv AS SELECT CampaignName FROM CAMPAIGN_PERFORMANCE_REPORT`,
+		`USE 123-456-7890`,
+	}
+	var endings = []struct {
+		s, out   string
+		end      awql.Terminator
+		vertical bool
+	}{
+		{s: "", end: awql.TerminatorEOF},
+		{s: ";", out: ";", end: awql.TerminatorSemicolon},
+		{s: `\G`, out: `\G`, end: awql.TerminatorG, vertical: true},
+		{s: `\G;`, out: `\G`, end: awql.TerminatorG, vertical: true},
+		{s: `\g ;`, out: `\G`, end: awql.TerminatorG, vertical: true},
+	}
+	for i, q := range queries {
+		for _, e := range endings {
+			stmts, err := awql.ParseString(q + e.s)
+			if err != nil {
+				t.Fatalf("%d. Expected no error with %q, received %v", i, q+e.s, err)
+			}
+			if end := stmts[0].Terminator(); end != e.end {
+				t.Errorf("%d. Expected %v with %q, received %v", i, e.end, q+e.s, end)
+			}
+			if v := stmts[0].VerticalOutput(); v != e.vertical {
+				t.Errorf("%d. Expected vertical output %v with %q, received %v", i, e.vertical, q+e.s, v)
+			}
+			if s, ok := stmts[0].(*awql.SelectStatement); ok && s.GModifier != e.vertical {
+				t.Errorf("%d. Expected the G modifier %v with %q, received %v", i, e.vertical, q+e.s, s.GModifier)
+			}
+			// Only the full string ends with the terminator.
+			if s := stmts[0].String(); s != q {
+				t.Errorf("%d. Expected %q with %q, received %q", i, q, q+e.s, s)
+			}
+			if s := stmts[0].FullString(); s != q+e.out {
+				t.Errorf("%d. Expected %q as full string with %q, received %q", i, q+e.out, q+e.s, s)
+			}
+			if len(stmts) != 1 {
+				t.Errorf("%d. Expected one statement with %q, received %d", i, q+e.s, len(stmts))
+			}
+		}
+		// The G modifier can not follow the semicolon.
+		if _, err := awql.ParseString(q + `;\G`); err == nil {
+			t.Errorf("%d. Expected an error with %q", i, q+`;\G`)
+		}
+	}
+	// The deprecated G modifier is still honoured.
+	s := awql.Statement{GModifier: true}
+	if !s.VerticalOutput() || s.Terminator() != awql.TerminatorG {
+		t.Errorf("Expected a vertical output with the G modifier, received %v", s.Terminator())
+	}
+	s.End = awql.TerminatorSemicolon
+	if s.VerticalOutput() {
+		t.Error("Expected the terminator to take precedence over the G modifier")
+	}
+	if s := awql.Terminator(42).String(); s != "Terminator(42)" {
+		t.Errorf("Expected Terminator(42), received %q", s)
+	}
+}
@@ -1,5 +1,7 @@
 package awqlparse
 
+import "strconv"
+
 /*
 Base AWQL grammar
 https://developers.google.com/adwords/api/docs/guides/awql#grammar
@@ -12,6 +14,8 @@ https://github.com/rvflash/awql/
 type Token int
 
 // List of special runes or reserved keywords.
+// The values of the tokens are part of the API: a new token is always
+// appended at the end of the list, never inserted among the existing ones.
 const (
 	// Special tokens
 	ILLEGAL Token = iota
@@ -21,12 +25,12 @@ const (
 	G_MODIFIER // \G ou \g
 
 	// Literals
-	IDENTIFIER  // base element
-	WHITE_SPACE // white space
-	STRING      // char between single or double quotes
-	STRING_LIST
-	VALUE_LITERAL // [a-zA-Z0-9_.]
-	VALUE_LITERAL_LIST
+	IDENTIFIER         // base element
+	WHITE_SPACE        // white space
+	STRING             // char between single or double quotes
+	STRING_LIST        // list of strings, built by the parser
+	VALUE_LITERAL      // [a-zA-Z0-9_.]
+	VALUE_LITERAL_LIST // list of value literals, built by the parser
 
 	// Misc characters
 	ASTERISK              // *
@@ -77,4 +81,113 @@ const (
 	ASC
 	DESC
 	LIMIT
+
+	// Extended keywords
+	USE
+
+	// Unterminated literals
+	UNTERMINATED_STRING // string or quoted identifier without closing quote
+
+	// Wildcard modifier
+	EXCEPT
+
+	// Parameter
+	PLACEHOLDER       // ?
+	NAMED_PLACEHOLDER // @name or :name
+
+	// Guard
+	TOO_LONG // token exceeding its maximum size, truncated
 )
+
+// tokenNames lists the names of the tokens.
+var tokenNames = [...]string{
+	ILLEGAL:                      "ILLEGAL",
+	EOF:                          "EOF",
+	DIGIT:                        "DIGIT",
+	DECIMAL:                      "DECIMAL",
+	G_MODIFIER:                   "G_MODIFIER",
+	IDENTIFIER:                   "IDENTIFIER",
+	WHITE_SPACE:                  "WHITE_SPACE",
+	STRING:                       "STRING",
+	STRING_LIST:                  "STRING_LIST",
+	VALUE_LITERAL:                "VALUE_LITERAL",
+	VALUE_LITERAL_LIST:           "VALUE_LITERAL_LIST",
+	ASTERISK:                     "ASTERISK",
+	COMMA:                        "COMMA",
+	LEFT_PARENTHESIS:             "LEFT_PARENTHESIS",
+	RIGHT_PARENTHESIS:            "RIGHT_PARENTHESIS",
+	LEFT_SQUARE_BRACKETS:         "LEFT_SQUARE_BRACKETS",
+	RIGHT_SQUARE_BRACKETS:        "RIGHT_SQUARE_BRACKETS",
+	SEMICOLON:                    "SEMICOLON",
+	EQUAL:                        "EQUAL",
+	DIFFERENT:                    "DIFFERENT",
+	SUPERIOR:                     "SUPERIOR",
+	SUPERIOR_OR_EQUAL:            "SUPERIOR_OR_EQUAL",
+	INFERIOR:                     "INFERIOR",
+	INFERIOR_OR_EQUAL:            "INFERIOR_OR_EQUAL",
+	IN:                           "IN",
+	NOT_IN:                       "NOT_IN",
+	STARTS_WITH:                  "STARTS_WITH",
+	STARTS_WITH_IGNORE_CASE:      "STARTS_WITH_IGNORE_CASE",
+	CONTAINS:                     "CONTAINS",
+	CONTAINS_IGNORE_CASE:         "CONTAINS_IGNORE_CASE",
+	DOES_NOT_CONTAIN:             "DOES_NOT_CONTAIN",
+	DOES_NOT_CONTAIN_IGNORE_CASE: "DOES_NOT_CONTAIN_IGNORE_CASE",
+	DESCRIBE:                     "DESCRIBE",
+	SELECT:                       "SELECT",
+	CREATE:                       "CREATE",
+	REPLACE:                      "REPLACE",
+	VIEW:                         "VIEW",
+	SHOW:                         "SHOW",
+	FULL:                         "FULL",
+	TABLES:                       "TABLES",
+	DISTINCT:                     "DISTINCT",
+	AS:                           "AS",
+	FROM:                         "FROM",
+	WHERE:                        "WHERE",
+	LIKE:                         "LIKE",
+	WITH:                         "WITH",
+	AND:                          "AND",
+	OR:                           "OR",
+	DURING:                       "DURING",
+	ORDER:                        "ORDER",
+	GROUP:                        "GROUP",
+	BY:                           "BY",
+	ASC:                          "ASC",
+	DESC:                         "DESC",
+	LIMIT:                        "LIMIT",
+	USE:                          "USE",
+	UNTERMINATED_STRING:          "UNTERMINATED_STRING",
+	EXCEPT:                       "EXCEPT",
+	PLACEHOLDER:                  "PLACEHOLDER",
+	NAMED_PLACEHOLDER:            "NAMED_PLACEHOLDER",
+	TOO_LONG:                     "TOO_LONG",
+}
+
+// String returns the name of the token.
+func (t Token) String() string {
+	if t >= 0 && int(t) < len(tokenNames) {
+		return tokenNames[t]
+	}
+	return "Token(" + strconv.Itoa(int(t)) + ")"
+}
+
+// IsKeyword returns true if the token is a reserved word.
+func (t Token) IsKeyword() bool {
+	tk, ok := keywords[t.String()]
+	return ok && tk == t
+}
+
+// IsOperator returns true if the token is an operator of condition.
+func (t Token) IsOperator() bool {
+	return isOperator(t)
+}
+
+// IsLiteral returns true if the token is a value: a number, an identifier, a string or a list.
+func (t Token) IsLiteral() bool {
+	switch t {
+	case DIGIT, DECIMAL, IDENTIFIER, STRING, STRING_LIST, VALUE_LITERAL, VALUE_LITERAL_LIST:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package awqlparse_test
+
+import (
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestToken_String(t *testing.T) {
+	var tests = []struct {
+		t awql.Token
+		s string
+	}{
+		{t: awql.ILLEGAL, s: "ILLEGAL"},
+		{t: awql.IDENTIFIER, s: "IDENTIFIER"},
+		{t: awql.LEFT_PARENTHESIS, s: "LEFT_PARENTHESIS"},
+		{t: awql.DOES_NOT_CONTAIN_IGNORE_CASE, s: "DOES_NOT_CONTAIN_IGNORE_CASE"},
+		{t: awql.SELECT, s: "SELECT"},
+		{t: awql.LIMIT, s: "LIMIT"},
+		{t: awql.Token(-1), s: "Token(-1)"},
+		{t: awql.Token(999), s: "Token(999)"},
+	}
+	for i, tt := range tests {
+		if s := tt.t.String(); s != tt.s {
+			t.Errorf("%d. Expected %q, received %q", i, tt.s, s)
+		}
+	}
+}
+
+func TestToken_Values(t *testing.T) {
+	// The new tokens are appended to keep the values of the existing ones.
+	var tests = []struct {
+		t awql.Token
+		v int
+	}{
+		{t: awql.ILLEGAL, v: 0},
+		{t: awql.STRING, v: 7},
+		{t: awql.STRING_LIST, v: 8},
+		{t: awql.DOES_NOT_CONTAIN_IGNORE_CASE, v: 31},
+		{t: awql.SHOW, v: 37},
+		{t: awql.FULL, v: 38},
+		{t: awql.LIMIT, v: 54},
+		{t: awql.USE, v: 55},
+		{t: awql.UNTERMINATED_STRING, v: 56},
+		{t: awql.EXCEPT, v: 57},
+		{t: awql.PLACEHOLDER, v: 58},
+		{t: awql.NAMED_PLACEHOLDER, v: 59},
+		{t: awql.TOO_LONG, v: 60},
+	}
+	for i, tt := range tests {
+		if int(tt.t) != tt.v {
+			t.Errorf("%d. Expected the value %d for %v, received %d", i, tt.v, tt.t, int(tt.t))
+		}
+	}
+}
+
+func TestToken_Is(t *testing.T) {
+	var tests = []struct {
+		t                          awql.Token
+		keyword, operator, literal bool
+	}{
+		{t: awql.EOF},
+		{t: awql.COMMA},
+		{t: awql.SELECT, keyword: true},
+		{t: awql.DESC, keyword: true},
+		{t: awql.EQUAL, operator: true},
+		{t: awql.NOT_IN, keyword: true, operator: true},
+		{t: awql.DIGIT, literal: true},
+		{t: awql.IDENTIFIER, literal: true},
+		{t: awql.STRING_LIST, literal: true},
+	}
+	for i, tt := range tests {
+		if b := tt.t.IsKeyword(); b != tt.keyword {
+			t.Errorf("%d. Expected IsKeyword to be %v for %v", i, tt.keyword, tt.t)
+		}
+		if b := tt.t.IsOperator(); b != tt.operator {
+			t.Errorf("%d. Expected IsOperator to be %v for %v", i, tt.operator, tt.t)
+		}
+		if b := tt.t.IsLiteral(); b != tt.literal {
+			t.Errorf("%d. Expected IsLiteral to be %v for %v", i, tt.literal, tt.t)
+		}
+	}
+}
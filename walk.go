@@ -0,0 +1,61 @@
+package awqlparse
+
+// DuringValue is a date or a date range literal of the during clause, as visited by Walk.
+type DuringValue string
+
+// Walk traverses the statement in depth-first order and calls fn for each node:
+// the statement itself, then its fields (DynamicField), the columns excluded from
+// the wildcard (Field), its conditions (Condition),
+// the values of its during clause (DuringValue), its groups (FieldPosition),
+// its orders (Orderer) and finally the select statement used as source of a view.
+// The walk stops as soon as fn returns false.
+func Walk(stmt Stmt, fn func(node interface{}) bool) {
+	walk(stmt, fn)
+}
+
+// walk traverses the statement and returns false if the walk has been stopped.
+func walk(stmt Stmt, fn func(node interface{}) bool) bool {
+	if stmt == nil || !fn(stmt) {
+		return false
+	}
+	if s, ok := stmt.(DataStmt); ok {
+		for _, f := range s.Columns() {
+			if !fn(f) {
+				return false
+			}
+		}
+	}
+	if s, ok := stmt.(SelectStmt); ok {
+		for _, e := range s.ExcludedList() {
+			if !fn(e) {
+				return false
+			}
+		}
+		for _, c := range s.ConditionList() {
+			if !fn(c) {
+				return false
+			}
+		}
+		for _, d := range s.DuringList() {
+			if !fn(DuringValue(d)) {
+				return false
+			}
+		}
+		for _, g := range s.GroupList() {
+			if !fn(g) {
+				return false
+			}
+		}
+		for _, o := range s.OrderList() {
+			if !fn(o) {
+				return false
+			}
+		}
+	}
+	if s, ok := stmt.(CreateViewStmt); ok {
+		if v := s.SourceQuery(); !isNilSelect(v) {
+			return walk(v, fn)
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package awqlparse_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	awql "github.com/rvflash/awql-parser"
+)
+
+func TestWalk(t *testing.T) {
+	q := `CREATE VIEW rv (id, clicks) AS SELECT CampaignId, SUM(Clicks) FROM CAMPAIGN_PERFORMANCE_REPORT
+	WHERE Clicks > 0 AND CampaignId IN [1, 2] DURING 20161224,20161225 GROUP BY 1 ORDER BY 2 DESC`
+	stmts, err := awql.NewParser(strings.NewReader(q)).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+
+	// Counts the references to each column of the report.
+	refs := make(map[string]int)
+	var nodes []string
+	awql.Walk(stmts[0], func(node interface{}) bool {
+		switch n := node.(type) {
+		case awql.CreateViewStmt:
+			nodes = append(nodes, "view")
+		case awql.SelectStmt:
+			nodes = append(nodes, "select")
+		case awql.Orderer:
+			nodes = append(nodes, "order")
+			refs[n.Name()]++
+		case awql.FieldPosition:
+			nodes = append(nodes, "group")
+			refs[n.Name()]++
+		case awql.DynamicField:
+			nodes = append(nodes, "field")
+			refs[n.Name()]++
+		case awql.Condition:
+			nodes = append(nodes, "condition")
+			refs[n.Name()]++
+		case awql.DuringValue:
+			nodes = append(nodes, "during")
+		}
+		return true
+	})
+	expectedRefs := map[string]int{"id": 1, "clicks": 1, "CampaignId": 3, "Clicks": 3}
+	if !reflect.DeepEqual(refs, expectedRefs) {
+		t.Errorf("Expected %v as references, received %v", expectedRefs, refs)
+	}
+	expectedNodes := []string{
+		"view", "field", "field", "select", "field", "field",
+		"condition", "condition", "during", "during", "group", "order",
+	}
+	if !reflect.DeepEqual(nodes, expectedNodes) {
+		t.Errorf("Expected %v as nodes, received %v", expectedNodes, nodes)
+	}
+
+	// Stops the walk on the first condition.
+	var size int
+	awql.Walk(stmts[0], func(node interface{}) bool {
+		size++
+		_, ok := node.(awql.Condition)
+		return !ok
+	})
+	if size != 7 {
+		t.Errorf("Expected 7 visited nodes, received %d", size)
+	}
+}
+
+func TestWalk_Excluded(t *testing.T) {
+	q := `SELECT * EXCEPT (Cost, Clicks) FROM CAMPAIGN_PERFORMANCE_REPORT WHERE Impressions > 0`
+	stmt, err := awql.ParseSelectString(q)
+	if err != nil {
+		t.Fatalf("Expected no error with %q, received %v", q, err)
+	}
+	var nodes []string
+	awql.Walk(stmt, func(node interface{}) bool {
+		switch n := node.(type) {
+		case awql.DynamicField:
+			nodes = append(nodes, "field "+n.Name())
+		case awql.Condition:
+			nodes = append(nodes, "condition "+n.Name())
+		case awql.Field:
+			nodes = append(nodes, "excluded "+n.Name())
+		}
+		return true
+	})
+	expected := []string{"field *", "excluded Cost", "excluded Clicks", "condition Impressions"}
+	if !reflect.DeepEqual(nodes, expected) {
+		t.Errorf("Expected %v as nodes, received %v", expected, nodes)
+	}
+}